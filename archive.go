@@ -0,0 +1,260 @@
+package paramstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// the format written in the header of every archive.
+	archiveFormat = "paramstore-archive"
+
+	// the version of the archive format written by Export.
+	archiveVersion = 1
+)
+
+// archiveHeader is the first line of an archive.
+type archiveHeader struct {
+	Format   string    `json:"format"`
+	Version  int       `json:"version"`
+	Path     string    `json:"path"`
+	Exported time.Time `json:"exported"`
+}
+
+// archiveParameter is a single parameter in an archive, written on its own
+// line after the header.
+type archiveParameter struct {
+	Name        string        `json:"name"`
+	Value       string        `json:"value"`
+	Type        ParameterType `json:"type"`
+	Tier        ParameterTier `json:"tier,omitempty"`
+	Description string        `json:"description,omitempty"`
+	KeyId       string        `json:"keyId,omitempty"`
+	Tags        Tags          `json:"tags,omitempty"`
+	Labels      []string      `json:"labels,omitempty"`
+}
+
+// Export writes every param under the given path to the given writer, as an
+// archive that can be restored with Import(). The archive is a header line,
+// followed by one JSON object per param, including its type, tier,
+// description, tags, KMS key and the labels on its latest version.
+// NOTE: SecureString values are always decrypted, so they can be restored;
+// the archive must be stored as carefully as the params themselves.
+func (c *Client) Export(ctx context.Context, path string, w io.Writer) (errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Export")
+	defer span.End()
+
+	// retrieve params, with metadata.
+	decrypting := *c
+	decrypting.withDecryption = true
+	params, err := decrypting.GetByPath(newCtx, path, true)
+	if err != nil {
+		return err
+	}
+	metadata, err := c.describeByPath(newCtx, path)
+	if err != nil {
+		return err
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+
+	// write header.
+	e := json.NewEncoder(w)
+	if err := e.Encode(archiveHeader{
+		Format:   archiveFormat,
+		Version:  archiveVersion,
+		Path:     path,
+		Exported: time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+
+	// write params, one at a time.
+	for _, p := range params {
+		a := archiveParameter{
+			Name:  p.Name,
+			Value: p.Value,
+			Type:  p.Type,
+		}
+		if m, ok := metadata[p.Name]; ok {
+			a.Tier = ParameterTier(m.Tier)
+			a.Description = aws.ToString(m.Description)
+			a.KeyId = aws.ToString(m.KeyId)
+		}
+
+		// retrieve tags.
+		if a.Tags, err = c.Tags(newCtx, p.Name); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		// retrieve labels on the latest version.
+		history, err := c.History(newCtx, p.Name)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		for _, v := range history {
+			if v.Version == p.Version {
+				a.Labels = v.Labels
+			}
+		}
+		if err := e.Encode(a); err != nil {
+			return multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ConflictPolicy determines what Import() does with a param that already
+// exists.
+type ConflictPolicy string
+
+const (
+	ConflictFail      ConflictPolicy = "fail"      // Nothing is imported if any param already exists.
+	ConflictSkip      ConflictPolicy = "skip"      // Params that already exist are left as they are.
+	ConflictOverwrite ConflictPolicy = "overwrite" // Params that already exist are overwritten.
+)
+
+// ImportOptions configures how an archive is restored.
+type ImportOptions struct {
+	Conflict ConflictPolicy // What to do with params that already exist; defaults to ConflictFail.
+	Path     string         // If set, replaces the path the archive was exported from in each name.
+}
+
+// ImportResult is the outcome of Import().
+type ImportResult struct {
+	Imported []string // The names of the params imported.
+	Skipped  []string // The names of the params skipped, since they already exist.
+}
+
+// Import restores every param in an archive written by Export() from the given
+// reader, including their tags and labels.
+func (c *Client) Import(ctx context.Context, r io.Reader, opts ImportOptions) (result ImportResult, errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Import")
+	defer span.End()
+
+	// determine conflict policy.
+	conflict := opts.Conflict
+	switch conflict {
+	case "":
+		conflict = ConflictFail
+	case ConflictFail, ConflictSkip, ConflictOverwrite:
+	default:
+		return result, ErrInvalidConflictPolicy{conflict}
+	}
+
+	// read header.
+	d := json.NewDecoder(bufio.NewReader(r))
+	var header archiveHeader
+	if err := d.Decode(&header); err != nil {
+		return result, ErrInvalidArchive{err.Error()}
+	}
+	if header.Format != archiveFormat {
+		return result, ErrInvalidArchive{"missing archive header"}
+	}
+	if header.Version != archiveVersion {
+		return result, ErrInvalidArchive{fmt.Sprintf("unsupported archive version %v", header.Version)}
+	}
+
+	// read params.
+	var archived []archiveParameter
+	for {
+		var a archiveParameter
+		if err := d.Decode(&a); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return result, ErrInvalidArchive{err.Error()}
+		}
+		if opts.Path != "" {
+			base := strings.TrimRight(header.Path, "/")
+			a.Name = strings.TrimRight(opts.Path, "/") + strings.TrimPrefix(a.Name, base)
+		}
+		archived = append(archived, a)
+	}
+	if len(archived) == 0 {
+		return result, nil
+	}
+
+	// determine conflicts.
+	names := make([]string, len(archived))
+	for i, a := range archived {
+		names[i] = a.Name
+	}
+	found, err := c.GetMultiple(newCtx, names...)
+	if err != nil && !onlyInvalidParameters(err) {
+		return result, err
+	}
+	exists := make(map[string]bool, len(found))
+	for _, p := range found {
+		exists[p.Name] = true
+	}
+	if conflict == ConflictFail {
+		var conflicts []string
+		for _, n := range names {
+			if exists[n] {
+				conflicts = append(conflicts, n)
+			}
+		}
+		if len(conflicts) > 0 {
+			return result, ErrImportConflict{conflicts}
+		}
+	}
+
+	// validate params, before making any changes.
+	var restore []archiveParameter
+	var params Parameters
+	for _, a := range archived {
+		if exists[a.Name] && conflict == ConflictSkip {
+			result.Skipped = append(result.Skipped, a.Name)
+			continue
+		}
+		restore = append(restore, a)
+		params = append(params, Parameter{
+			Name:        a.Name,
+			Value:       a.Value,
+			Type:        a.Type,
+			Tier:        a.Tier,
+			Description: a.Description,
+			KeyId:       a.KeyId,
+			Overwrite:   exists[a.Name],
+		})
+	}
+	if _, _, _, err := c.prepare(newCtx, params); err != nil {
+		return result, err
+	}
+
+	// restore params, then their tags and labels.
+	for i, p := range params {
+		if err := c.Put(newCtx, Parameters{p}); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		a := restore[i]
+		if len(a.Tags) > 0 {
+			if err := c.Tag(newCtx, p.Name, a.Tags); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		if len(a.Labels) > 0 {
+			if err := c.Label(newCtx, p.Name, 0, a.Labels...); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		result.Imported = append(result.Imported, p.Name)
+	}
+	return result, errs
+}
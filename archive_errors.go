@@ -0,0 +1,36 @@
+package paramstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidArchive is returned when Import() is given something that isn't an
+// archive written by Export().
+type ErrInvalidArchive struct {
+	reason string
+}
+
+func (e ErrInvalidArchive) Error() string {
+	return fmt.Sprintf("invalid archive: %v", e.reason)
+}
+
+// ErrImportConflict is returned when Import() is used with ConflictFail, and
+// one or more params in the archive already exist.
+type ErrImportConflict struct {
+	Names []string
+}
+
+func (e ErrImportConflict) Error() string {
+	return fmt.Sprintf("parameters already exist: %v", strings.Join(e.Names, ", "))
+}
+
+// ErrInvalidConflictPolicy is returned when Import() is given an unknown
+// ConflictPolicy.
+type ErrInvalidConflictPolicy struct {
+	policy ConflictPolicy
+}
+
+func (e ErrInvalidConflictPolicy) Error() string {
+	return fmt.Sprintf("%q is not a conflict policy", e.policy)
+}
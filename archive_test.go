@@ -0,0 +1,144 @@
+package paramstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	source := Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString, Description: "The database host."},
+		{Name: "/myapp/prod/password", Value: "hunter2", Type: ParameterTypeSecureString, KeyId: "alias/myapp"},
+		{Name: "/myapp/staging/host", Value: "db.staging", Type: ParameterTypeString},
+	}
+
+	// export params.
+	mock, _ := newMockSSMStore(source)
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	if err := c.Tag(ctx, "/myapp/prod/host", Tags{"team": "platform"}); err != nil {
+		t.Fatalf("Tag() returned an error; error=%v", err)
+	}
+	if err := c.Label(ctx, "/myapp/prod/host", 0, "stable"); err != nil {
+		t.Fatalf("Label() returned an error; error=%v", err)
+	}
+	var archive bytes.Buffer
+	if err := c.Export(ctx, "/myapp/prod", &archive); err != nil {
+		t.Fatalf("Export() returned an error; error=%v", err)
+	}
+
+	tests := map[string]struct {
+		existing Parameters
+		opts     ImportOptions
+		want     ImportResult
+		values   map[string]string
+		err      error
+	}{
+		"import into empty store": {
+			want: ImportResult{Imported: []string{"/myapp/prod/host", "/myapp/prod/password"}},
+			values: map[string]string{
+				"/myapp/prod/host":     "db.prod",
+				"/myapp/prod/password": "hunter2",
+			},
+		},
+		"import with rewritten path": {
+			opts: ImportOptions{Path: "/restored/"},
+			want: ImportResult{Imported: []string{"/restored/host", "/restored/password"}},
+			values: map[string]string{
+				"/restored/host":     "db.prod",
+				"/restored/password": "hunter2",
+			},
+		},
+		"skip existing params": {
+			existing: Parameters{{Name: "/myapp/prod/host", Value: "changed", Type: ParameterTypeString}},
+			opts:     ImportOptions{Conflict: ConflictSkip},
+			want: ImportResult{
+				Imported: []string{"/myapp/prod/password"},
+				Skipped:  []string{"/myapp/prod/host"},
+			},
+			values: map[string]string{"/myapp/prod/host": "changed"},
+		},
+		"overwrite existing params": {
+			existing: Parameters{{Name: "/myapp/prod/host", Value: "changed", Type: ParameterTypeString}},
+			opts:     ImportOptions{Conflict: ConflictOverwrite},
+			want:     ImportResult{Imported: []string{"/myapp/prod/host", "/myapp/prod/password"}},
+			values:   map[string]string{"/myapp/prod/host": "db.prod"},
+		},
+		"catch conflict": {
+			existing: Parameters{{Name: "/myapp/prod/host", Value: "changed", Type: ParameterTypeString}},
+			err:      ErrImportConflict{[]string{"/myapp/prod/host"}},
+			values:   map[string]string{"/myapp/prod/host": "changed"},
+		},
+		"catch invalid conflict policy": {
+			opts: ImportOptions{Conflict: "merge"},
+			err:  ErrInvalidConflictPolicy{"merge"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, store := newMockSSMStore(tt.existing)
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			got, err := c.Import(ctx, bytes.NewReader(archive.Bytes()), tt.opts)
+			if tt.err != nil {
+				if err == nil || err.Error() != tt.err.Error() {
+					t.Errorf("Import() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+			} else if err != nil {
+				t.Errorf("Import() returned an error; error=%v", err)
+				return
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Import() returned an unexpected result;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+			for n, want := range tt.values {
+				if got, _ := store.Value(n); got != want {
+					t.Errorf("Import() left an unexpected value for %v; want=%v, got=%v", n, want, got)
+				}
+			}
+		})
+	}
+
+	// check metadata, tags and labels are restored.
+	mock, _ = newMockSSMStore(nil)
+	c = &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	if _, err := c.Import(ctx, bytes.NewReader(archive.Bytes()), ImportOptions{}); err != nil {
+		t.Fatalf("Import() returned an error; error=%v", err)
+	}
+	tags, err := c.Tags(ctx, "/myapp/prod/host")
+	if err != nil || !reflect.DeepEqual(tags, Tags{"team": "platform"}) {
+		t.Errorf("Import() didn't restore tags; got=%v, err=%v", tags, err)
+	}
+	history, err := c.History(ctx, "/myapp/prod/host")
+	if err != nil || len(history) != 1 {
+		t.Fatalf("History() returned unexpected history; got=%+v, err=%v", history, err)
+	}
+	if h := history[0]; h.Description != "The database host." || !reflect.DeepEqual(h.Labels, []string{"stable"}) {
+		t.Errorf("Import() didn't restore description and labels; got=%+v", h)
+	}
+	history, err = c.History(ctx, "/myapp/prod/password")
+	if err != nil || history[0].KeyId != "alias/myapp" {
+		t.Errorf("Import() didn't restore KMS key; got=%+v, err=%v", history, err)
+	}
+}
+
+func Test_ImportInvalidArchive(t *testing.T) {
+	tests := map[string]string{
+		"empty":           "",
+		"missing header":  `{"name":"/a","value":"b","type":"String"}`,
+		"unknown version": `{"format":"paramstore-archive","version":99}`,
+		"malformed param": `{"format":"paramstore-archive","version":1}` + "\n{",
+	}
+	for name, archive := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: &mockSSMClient{}}
+			_, err := c.Import(context.Background(), strings.NewReader(archive), ImportOptions{})
+			if !errors.As(err, &ErrInvalidArchive{}) {
+				t.Errorf("Import() returned an unexpected error; want=ErrInvalidArchive, got=%v", err)
+			}
+		})
+	}
+}
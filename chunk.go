@@ -0,0 +1,339 @@
+package paramstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// the format written in every chunk manifest.
+	chunkManifestFormat = "paramstore-chunks"
+
+	// the version of the chunk manifest format written by Put.
+	chunkManifestVersion = 1
+
+	// the segment added to the name of a param to store its chunks under, such
+	// as "/myapp/cert/_chunks/<id>/0".
+	chunksSegment = "_chunks"
+
+	// the max size of a chunk, so chunks fit in the standard tier.
+	chunkSize = maxStandardValueSize
+
+	// the max number of chunks a value can be split into.
+	maxChunks = 100
+)
+
+// chunkManifest is the value of a param whose value is split into chunks,
+// stored as child params.
+type chunkManifest struct {
+	Format  string        `json:"format"`
+	Version int           `json:"version"`
+	Path    string        `json:"path"`   // The full path the chunks are stored under.
+	Type    ParameterType `json:"type"`   // The type of the value that was split.
+	Chunks  int           `json:"chunks"` // The number of chunks.
+	Size    int           `json:"size"`   // The size of the value, in bytes.
+	SHA256  string        `json:"sha256"` // The checksum of the value.
+}
+
+// valueLimit returns the max size of a value for a param in the given tier.
+func valueLimit(tier ParameterTier) int {
+	switch tier {
+	case ParameterTierAdvanced, ParameterTierIntelligentTiering:
+		return maxAdvancedValueSize
+	}
+	return maxStandardValueSize
+}
+
+// splitValue splits the given value into chunks of at most size bytes,
+// without splitting a UTF-8 character across chunks.
+func splitValue(value string, size int) (out []string) {
+	for len(value) > size {
+		n := size
+		for n > 0 && !utf8.RuneStart(value[n]) {
+			n--
+		}
+		out = append(out, value[:n])
+		value = value[n:]
+	}
+	return append(out, value)
+}
+
+// chunk converts the given (qualified) param into a manifest param, with the
+// value replaced by the manifest, and a param for each chunk of the value.
+// Chunks are stored under a path derived from the checksum of the value, so a
+// new value never overwrites the chunks of the current value.
+func chunk(p Parameter) (Parameter, Parameters) {
+	sum := sha256.Sum256([]byte(p.Value))
+	m := chunkManifest{
+		Format:  chunkManifestFormat,
+		Version: chunkManifestVersion,
+		Path:    p.Name + "/" + chunksSegment + "/" + hex.EncodeToString(sum[:8]),
+		Type:    p.Type,
+		Size:    len(p.Value),
+		SHA256:  hex.EncodeToString(sum[:]),
+	}
+
+	// determine the type of the manifest and chunks; StringList values are
+	// split as plain strings.
+	t := p.Type
+	if t == ParameterTypeStringList {
+		t = ParameterTypeString
+	}
+	values := splitValue(p.Value, chunkSize)
+	m.Chunks = len(values)
+	chunks := make(Parameters, len(values))
+	for i, v := range values {
+		chunks[i] = Parameter{
+			Name:      fmt.Sprintf("%v/%v", m.Path, i),
+			Value:     v,
+			Type:      t,
+			Tier:      ParameterTierStandard,
+			KeyId:     p.KeyId,
+			Overwrite: true,
+		}
+	}
+	b, _ := json.Marshal(m)
+	p.Value, p.Type = string(b), t
+	return p, chunks
+}
+
+// parseManifest parses the given value as a chunk manifest, returning false if
+// the value isn't a manifest.
+func parseManifest(value string) (chunkManifest, bool) {
+	var m chunkManifest
+	if !strings.HasPrefix(value, `{"format":"`+chunkManifestFormat+`"`) {
+		return m, false
+	}
+	if err := json.Unmarshal([]byte(value), &m); err != nil || m.Format != chunkManifestFormat {
+		return m, false
+	}
+	return m, true
+}
+
+// check returns an error if this manifest, of the given (qualified) param,
+// refers to chunks outside of the param, or to more chunks than the size of
+// the value needs, since a manifest is only the value of a param, which can be
+// written by anyone allowed to put the param.
+func (m chunkManifest) check(qualified string) error {
+
+	// NOTE: a chunk can be up to utf8.UTFMax-1 bytes smaller than chunkSize,
+	// since a character is never split across chunks.
+	size := chunkSize - utf8.UTFMax + 1
+	needed := (m.Size + size - 1) / size
+	if m.Chunks < 1 || m.Chunks > needed || m.Chunks > maxChunks {
+		return fmt.Errorf("manifest has an invalid number of chunks (%v) for %v bytes", m.Chunks, m.Size)
+	}
+	id, ok := strings.CutPrefix(m.Path, qualified+"/"+chunksSegment+"/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return fmt.Errorf("manifest refers to chunks outside of the parameter (%q)", m.Path)
+	}
+	return nil
+}
+
+// isChunk returns true if the given name is a chunk of another param.
+func isChunk(name string) bool {
+	return strings.Contains(name, "/"+chunksSegment+"/")
+}
+
+// chunkAll splits the value of each of the given (qualified) params that is
+// too large for its tier into chunks, replacing the value with a manifest,
+// returning the chunks for each param, keyed by name.
+func chunkAll(qualified Parameters) map[string]Parameters {
+	chunks := make(map[string]Parameters)
+	for i, p := range qualified {
+		if len(p.Value) <= valueLimit(p.Tier) {
+			continue
+		}
+		qualified[i], chunks[p.Name] = chunk(p)
+	}
+	return chunks
+}
+
+// chunkValues splits the value of each of the given (qualified) params that is
+// too large for its tier into chunks, if this client is configured to, in the
+// same way as chunkAll, returning an error for any value that can't be split
+// into valid chunks.
+func (c *Client) chunkValues(qualified Parameters) (chunks map[string]Parameters, errs error) {
+	if !c.largeValues {
+		return nil, nil
+	}
+	chunks = chunkAll(qualified)
+	for n, ch := range chunks {
+		if len(ch) > maxChunks {
+			errs = multierror.Append(errs, ErrInvalidValue{n, fmt.Sprintf("value is too large, even when split into %v chunks", maxChunks)})
+			continue
+		}
+		if err := ch.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return chunks, errs
+}
+
+// putChunks uploads the given chunks, one at a time.
+func (c *Client) putChunks(ctx context.Context, chunks Parameters) error {
+	for _, ch := range chunks {
+		if _, err := c.ssmsvc.PutParameter(ctx, c.putInput(ch)); err != nil {
+			c.logger.Error("failed to put chunk",
+				"error", err,
+				"name", ch.Name,
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupChunks deletes every chunk stored under the given (qualified) name,
+// except for the chunks under the given path, if any.
+func (c *Client) cleanupChunks(ctx context.Context, name, keep string) error {
+
+	// find chunks.
+	found, err := c.describe(ctx, types.ParameterStringFilter{
+		Key:    aws.String("Path"),
+		Option: aws.String("Recursive"),
+		Values: []string{name + "/" + chunksSegment},
+	})
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for n := range found {
+		qualified, err := c.qualify(n)
+		if err != nil {
+			return err
+		}
+		if keep == "" || !strings.HasPrefix(qualified, keep+"/") {
+			stale = append(stale, qualified)
+		}
+	}
+
+	// delete stale chunks in batches.
+	for i := 0; i < len(stale); i += c.batchSize {
+
+		// determine rolling batch size.
+		size := i + c.batchSize
+		if size > len(stale) {
+			size = len(stale)
+		}
+
+		// delete chunks.
+		in := &ssm.DeleteParametersInput{
+			Names: stale[i:size],
+		}
+		if _, err := c.ssmsvc.DeleteParameters(ctx, in); err != nil {
+			c.logger.Error("failed to delete stale chunks",
+				"error", err,
+				"names", in.Names,
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+// discardChunks deletes the chunks put for the given (qualified) name by a
+// write that failed, keeping the chunks the current value of the param refers
+// to, if any. Failures are only logged, since the write already failed.
+func (c *Client) discardChunks(ctx context.Context, name string) {
+	current, err := c.current(ctx, []string{name})
+	if err == nil {
+		m, _ := parseManifest(current[name].Value)
+		err = c.cleanupChunks(ctx, name, m.Path)
+	}
+	if err != nil {
+		c.logger.Warn("failed to discard chunks",
+			"error", err,
+			"name", name,
+		)
+	}
+}
+
+// assemble replaces the value of each of the given params that is a chunk
+// manifest with the value reassembled from its chunks, verifying the manifest
+// and checksum. The qualified name of each param is given in qualified, or nil
+// if the params already have qualified names. Chunks found in known, keyed by
+// their full name, aren't retrieved again.
+func (c *Client) assemble(ctx context.Context, params Parameters, qualified []string, known map[string]string) (errs error) {
+	for i, p := range params {
+		m, ok := parseManifest(p.Value)
+		if !ok {
+			continue
+		}
+
+		// check manifest.
+		name := p.Name
+		if qualified != nil {
+			name = qualified[i]
+		}
+		if err := m.check(name); err != nil {
+			c.logger.Error("failed to reassemble parameter",
+				"error", err,
+				"name", p.Name,
+			)
+			errs = multierror.Append(errs, ErrInvalidChunks{p.Name, err.Error()})
+			continue
+		}
+
+		// retrieve missing chunks.
+		names := make([]string, m.Chunks)
+		var missing []string
+		for j := range names {
+			names[j] = fmt.Sprintf("%v/%v", m.Path, j)
+			if _, ok := known[names[j]]; !ok {
+				missing = append(missing, names[j])
+			}
+		}
+		if len(missing) > 0 {
+			found, err := c.current(ctx, missing)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			if known == nil {
+				known = make(map[string]string, len(found))
+			}
+			for n, ch := range found {
+				known[n] = ch.Value
+			}
+		}
+
+		// reassemble value.
+		var b strings.Builder
+		var err error
+		for _, n := range names {
+			v, ok := known[n]
+			if !ok {
+				err = ErrInvalidChunks{p.Name, fmt.Sprintf("chunk %q is missing", n)}
+				break
+			}
+			b.WriteString(v)
+		}
+		if err == nil {
+			sum := sha256.Sum256([]byte(b.String()))
+			if b.Len() != m.Size || hex.EncodeToString(sum[:]) != m.SHA256 {
+				err = ErrInvalidChunks{p.Name, "checksum mismatch"}
+			}
+		}
+		if err != nil {
+			c.logger.Error("failed to reassemble parameter",
+				"error", err,
+				"name", p.Name,
+			)
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		params[i].Value, params[i].Type = b.String(), m.Type
+	}
+	return errs
+}
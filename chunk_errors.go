@@ -0,0 +1,14 @@
+package paramstore
+
+import "fmt"
+
+// ErrInvalidChunks is returned when the value of a param split into chunks
+// can't be reassembled.
+type ErrInvalidChunks struct {
+	Name   string
+	reason string
+}
+
+func (e ErrInvalidChunks) Error() string {
+	return fmt.Sprintf("failed to reassemble %q: %v", e.Name, e.reason)
+}
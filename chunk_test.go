@@ -0,0 +1,359 @@
+package paramstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func Test_splitValue(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		size  int
+		want  []string
+	}{
+		"small value": {
+			value: "hello",
+			size:  8,
+			want:  []string{"hello"},
+		},
+		"exact multiple": {
+			value: "abcdef",
+			size:  3,
+			want:  []string{"abc", "def"},
+		},
+		"multi-byte characters": {
+			value: "aé€b",
+			size:  3,
+			want:  []string{"aé", "€", "b"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := splitValue(tt.value, tt.size)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitValue() returned unexpected chunks; want=%q, got=%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_LargeValues(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, withDecryption: true, largeValues: true}
+	chunks := func() (n int) {
+		for name := range s.parameters {
+			if isChunk(name) {
+				n++
+			}
+		}
+		return n
+	}
+	cert := strings.Repeat("-----CERT-----\n", 1000)
+
+	// put large value.
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/cert", Value: cert, Type: ParameterTypeSecureString}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if v, _ := s.Value("/myapp/prod/cert"); len(v) > maxStandardValueSize || !strings.Contains(v, chunkManifestFormat) {
+		t.Errorf("Put() didn't store a manifest; got=%v", v)
+	}
+	if n := chunks(); n != 4 {
+		t.Errorf("Put() stored an unexpected number of chunks; want=4, got=%v", n)
+	}
+
+	// get large value.
+	p, err := c.Get(ctx, "/myapp/prod/cert")
+	if err != nil {
+		t.Fatalf("Get() returned an error; error=%v", err)
+	}
+	if p.Value != cert || p.Type != ParameterTypeSecureString {
+		t.Errorf("Get() returned an unexpected value; got=%v bytes, type=%v", len(p.Value), p.Type)
+	}
+	params, err := c.GetByPath(ctx, "/myapp/prod", true)
+	if err != nil {
+		t.Fatalf("GetByPath() returned an error; error=%v", err)
+	}
+	if len(params) != 2 {
+		t.Errorf("GetByPath() returned chunks; got=%v", params.ToSliceString())
+	}
+	for _, p := range params {
+		if p.Name == "/myapp/prod/cert" && p.Value != cert {
+			t.Errorf("GetByPath() returned an unexpected value; got=%v bytes", len(p.Value))
+		}
+	}
+
+	// overwrite with another large value, which cleans up the old chunks.
+	cert = strings.Repeat("-----CERT-----\n", 500)
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/cert", Value: cert, Type: ParameterTypeSecureString, Overwrite: true}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if n := chunks(); n != 2 {
+		t.Errorf("Put() left stale chunks; want=2, got=%v", n)
+	}
+	if p, err := c.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Get() returned an unexpected value after overwrite; error=%v", err)
+	}
+
+	// catch corrupted chunk.
+	for name := range s.parameters {
+		if isChunk(name) && strings.HasSuffix(name, "/0") {
+			_, _ = s.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("corrupted"), Overwrite: aws.Bool(true)})
+		}
+	}
+	if _, err := c.Get(ctx, "/myapp/prod/cert"); !errors.As(err, &ErrInvalidChunks{}) {
+		t.Errorf("Get() returned an unexpected error; want=ErrInvalidChunks, got=%v", err)
+	}
+
+	// overwrite with a small value, which cleans up every chunk.
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/cert", Value: "small", Type: ParameterTypeSecureString, Overwrite: true}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if n := chunks(); n != 0 {
+		t.Errorf("Put() left stale chunks; want=0, got=%v", n)
+	}
+
+	// delete large value, including its chunks.
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/cert", Value: cert, Overwrite: true}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if err := c.Delete(ctx, "/myapp/prod/cert"); err != nil {
+		t.Fatalf("Delete() returned an error; error=%v", err)
+	}
+	if n := chunks(); n != 0 {
+		t.Errorf("Delete() left chunks; want=0, got=%v", n)
+	}
+
+	// catch value too large, even when split into chunks.
+	huge := strings.Repeat("x", maxChunks*chunkSize+1)
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/huge", Value: huge, Type: ParameterTypeString}}); !errors.As(err, &ErrInvalidValue{}) {
+		t.Errorf("Put() returned an unexpected error; want=ErrInvalidValue, got=%v", err)
+	}
+}
+
+func Test_LargeValuesDisabled(t *testing.T) {
+	mock, _ := newMockSSMStore(nil)
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	err := c.Put(context.Background(), Parameters{{Name: "/myapp/prod/cert", Value: strings.Repeat("x", maxAdvancedValueSize+1)}})
+	if !errors.As(err, &ErrInvalidValue{}) {
+		t.Errorf("Put() returned an unexpected error; want=ErrInvalidValue, got=%v", err)
+	}
+}
+
+func Test_assembleForgedManifest(t *testing.T) {
+	manifest := func(path string, chunks, size int) string {
+		return fmt.Sprintf(`{"format":"paramstore-chunks","version":1,"path":%q,"type":"String","chunks":%v,"size":%v,"sha256":""}`, path, chunks, size)
+	}
+	tests := map[string]struct {
+		value  string
+		prefix string
+	}{
+		"negative chunks": {
+			value: manifest("/myapp/forged/_chunks/abc", -1, 10),
+		},
+		"zero chunks": {
+			value: manifest("/myapp/forged/_chunks/abc", 0, 0),
+		},
+		"huge chunks": {
+			value: manifest("/myapp/forged/_chunks/abc", 1<<40, 1<<50),
+		},
+		"too many chunks for size": {
+			value: manifest("/myapp/forged/_chunks/abc", 3, 10),
+		},
+		"path of another param": {
+			value: manifest("/other/secret/_chunks/abc", 1, 10),
+		},
+		"path outside chunks": {
+			value: manifest("/myapp/forged", 1, 10),
+		},
+		"nested path": {
+			value: manifest("/myapp/forged/_chunks/abc/def", 1, 10),
+		},
+		"unqualified path, with a prefix": {
+			value:  manifest("/forged/_chunks/abc", 1, 10),
+			prefix: "/myapp",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(Parameters{
+				{Name: "/myapp/forged", Value: tt.value, Type: ParameterTypeString},
+				{Name: "/other/secret/_chunks/abc/0", Value: "secret", Type: ParameterTypeString},
+			})
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, largeValues: true, prefix: tt.prefix}
+			get := "/myapp/forged"
+			if tt.prefix != "" {
+				get = "/forged"
+			}
+			if _, err := c.Get(context.Background(), get); !errors.As(err, &ErrInvalidChunks{}) {
+				t.Errorf("Get() returned an unexpected error; want=ErrInvalidChunks, got=%v", err)
+			}
+			if _, err := c.GetByPath(context.Background(), strings.TrimSuffix(get, "/forged")+"/", true); !errors.As(err, &ErrInvalidChunks{}) {
+				t.Errorf("GetByPath() returned an unexpected error; want=ErrInvalidChunks, got=%v", err)
+			}
+		})
+	}
+}
+
+func Test_LargeValuesConditional(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(nil)
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, largeValues: true}
+	chunks := func() (n int) {
+		for name := range s.parameters {
+			if isChunk(name) {
+				n++
+			}
+		}
+		return n
+	}
+	cert := strings.Repeat("-----CERT-----\n", 1000)
+
+	// put large value, only if absent.
+	if created, err := c.PutIfAbsent(ctx, Parameter{Name: "/myapp/prod/cert", Value: cert, Type: ParameterTypeSecureString}); err != nil || !created {
+		t.Fatalf("PutIfAbsent() returned an unexpected result; created=%v, error=%v", created, err)
+	}
+	if p, err := c.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Get() returned an unexpected value; error=%v", err)
+	}
+	if n := chunks(); n != 4 {
+		t.Errorf("PutIfAbsent() stored an unexpected number of chunks; want=4, got=%v", n)
+	}
+	if created, err := c.PutIfAbsent(ctx, Parameter{Name: "/myapp/prod/cert", Value: strings.Repeat("x", 10000), Type: ParameterTypeSecureString}); err != nil || created {
+		t.Errorf("PutIfAbsent() returned an unexpected result; created=%v, error=%v", created, err)
+	}
+	if n := chunks(); n != 4 {
+		t.Errorf("PutIfAbsent() left chunks for an existing param; want=4, got=%v", n)
+	}
+
+	// put large value, only if the version matches, which cleans up the old chunks.
+	cert = strings.Repeat("-----CERT-----\n", 500)
+	if version, err := c.PutIfVersion(ctx, Parameter{Name: "/myapp/prod/cert", Value: cert, Type: ParameterTypeSecureString}, 1); err != nil || version != 2 {
+		t.Fatalf("PutIfVersion() returned an unexpected result; version=%v, error=%v", version, err)
+	}
+	if p, err := c.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Get() returned an unexpected value; error=%v", err)
+	}
+	if n := chunks(); n != 2 {
+		t.Errorf("PutIfVersion() left stale chunks; want=2, got=%v", n)
+	}
+	if _, err := c.PutIfVersion(ctx, Parameter{Name: "/myapp/prod/cert", Value: strings.Repeat("x", 10000), Type: ParameterTypeSecureString}, 1); !errors.As(err, &ErrVersionConflict{}) {
+		t.Errorf("PutIfVersion() returned an unexpected error; want=ErrVersionConflict, got=%v", err)
+	}
+	if n := chunks(); n != 2 {
+		t.Errorf("PutIfVersion() left chunks for a stale version; want=2, got=%v", n)
+	}
+}
+
+func Test_LargeValuesTransaction(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(nil)
+	putParameter := mock.PutParameterFunc
+	mock.PutParameterFunc = func(ctx context.Context, input *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+		if aws.ToString(input.Name) == "/myapp/prod/fail" {
+			return nil, errors.New("boom")
+		}
+		return putParameter(ctx, input, optFns...)
+	}
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, largeValues: true}
+	chunks := func(name string) (n int) {
+		for n2 := range s.parameters {
+			if strings.HasPrefix(n2, name+"/"+chunksSegment+"/") {
+				n++
+			}
+		}
+		return n
+	}
+	cert := strings.Repeat("-----CERT-----\n", 1000)
+
+	// put large value.
+	if err := c.Transaction(ctx, func(tx *Tx) error {
+		tx.Put(Parameter{Name: "/myapp/prod/cert", Value: cert, Type: ParameterTypeSecureString})
+		return nil
+	}); err != nil {
+		t.Fatalf("Transaction() returned an error; error=%v", err)
+	}
+	if p, err := c.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Get() returned an unexpected value; error=%v", err)
+	}
+	if n := chunks("/myapp/prod/cert"); n != 4 {
+		t.Errorf("Transaction() stored an unexpected number of chunks; want=4, got=%v", n)
+	}
+
+	// roll back an overwrite and a create of large values, and a failed large
+	// value, leaving only the chunks of the prior value.
+	err := c.Transaction(ctx, func(tx *Tx) error {
+		tx.Put(
+			Parameter{Name: "/myapp/prod/cert", Value: strings.Repeat("-----CERT-----\n", 500), Type: ParameterTypeSecureString, Overwrite: true},
+			Parameter{Name: "/myapp/prod/key", Value: strings.Repeat("-----KEY-----\n", 1000), Type: ParameterTypeSecureString},
+			Parameter{Name: "/myapp/prod/fail", Value: strings.Repeat("-----FAIL-----\n", 1000), Type: ParameterTypeSecureString},
+		)
+		return nil
+	})
+	var txErr ErrTransactionFailed
+	if !errors.As(err, &txErr) || txErr.RollbackErr != nil {
+		t.Fatalf("Transaction() returned an unexpected error; want=ErrTransactionFailed, got=%v", err)
+	}
+	if p, err := c.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Transaction() didn't restore the prior value; error=%v", err)
+	}
+	for name, want := range map[string]int{"/myapp/prod/cert": 4, "/myapp/prod/key": 0, "/myapp/prod/fail": 0} {
+		if n := chunks(name); n != want {
+			t.Errorf("Transaction() left unexpected chunks for %v; want=%v, got=%v", name, want, n)
+		}
+	}
+
+	// delete large value, including its chunks.
+	if err := c.Transaction(ctx, func(tx *Tx) error {
+		tx.Delete("/myapp/prod/cert")
+		return nil
+	}); err != nil {
+		t.Fatalf("Transaction() returned an error; error=%v", err)
+	}
+	if n := chunks("/myapp/prod/cert"); n != 0 {
+		t.Errorf("Transaction() left chunks of a deleted param; want=0, got=%v", n)
+	}
+}
+
+func Test_LargeValuesPlanImport(t *testing.T) {
+	ctx := context.Background()
+	mock, _ := newMockSSMStore(nil)
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, withDecryption: true, largeValues: true}
+	cert := strings.Repeat("-----CERT-----\n", 1000)
+
+	// apply large value.
+	plan, err := c.Plan(ctx, Parameters{{Name: "/myapp/prod/cert", Value: cert, Type: ParameterTypeSecureString}}, "/myapp/prod", PlanOptions{})
+	if err != nil {
+		t.Fatalf("Plan() returned an error; error=%v", err)
+	}
+	if err := c.Apply(ctx, plan); err != nil {
+		t.Fatalf("Apply() returned an error; error=%v", err)
+	}
+	if p, err := c.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Get() returned an unexpected value after Apply(); error=%v", err)
+	}
+
+	// import large value, into another store.
+	var archive bytes.Buffer
+	if err := c.Export(ctx, "/myapp/prod", &archive); err != nil {
+		t.Fatalf("Export() returned an error; error=%v", err)
+	}
+	otherMock, _ := newMockSSMStore(nil)
+	other := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: otherMock, withDecryption: true, largeValues: true}
+	if _, err := other.Import(ctx, &archive, ImportOptions{}); err != nil {
+		t.Fatalf("Import() returned an error; error=%v", err)
+	}
+	if p, err := other.Get(ctx, "/myapp/prod/cert"); err != nil || p.Value != cert {
+		t.Errorf("Get() returned an unexpected value after Import(); error=%v", err)
+	}
+}
@@ -7,7 +7,10 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
-	"go.opentelemetry.io/otel"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jmpa-io/paramstore/internal/mockssm"
 )
 
 // iSSMClient is an interface for ssm.Client.
@@ -27,23 +30,91 @@ type iSSMClient interface {
 		params *ssm.DeleteParametersInput,
 		optFns ...func(*ssm.Options),
 	) (*ssm.DeleteParametersOutput, error)
+	GetParametersByPath(
+		ctx context.Context,
+		params *ssm.GetParametersByPathInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.GetParametersByPathOutput, error)
+	GetParameterHistory(
+		ctx context.Context,
+		params *ssm.GetParameterHistoryInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.GetParameterHistoryOutput, error)
+	LabelParameterVersion(
+		ctx context.Context,
+		params *ssm.LabelParameterVersionInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.LabelParameterVersionOutput, error)
+	AddTagsToResource(
+		ctx context.Context,
+		params *ssm.AddTagsToResourceInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.AddTagsToResourceOutput, error)
+	ListTagsForResource(
+		ctx context.Context,
+		params *ssm.ListTagsForResourceInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.ListTagsForResourceOutput, error)
+	RemoveTagsFromResource(
+		ctx context.Context,
+		params *ssm.RemoveTagsFromResourceInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.RemoveTagsFromResourceOutput, error)
+	DescribeParameters(
+		ctx context.Context,
+		params *ssm.DescribeParametersInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.DescribeParametersOutput, error)
+}
+
+// iSTSClient is an interface for sts.Client.
+type iSTSClient interface {
+	GetCallerIdentity(
+		ctx context.Context,
+		params *sts.GetCallerIdentityInput,
+		optFns ...func(*sts.Options),
+	) (*sts.GetCallerIdentityOutput, error)
 }
 
 // Client defines a client for this package.
 type Client struct {
 
 	// tracing.
-	tracerName string // The name of the tracer output in the traces.
+	tracerName     string               // The name of the tracer output in the traces.
+	tracerProvider trace.TracerProvider // The tracer provider used; the global tracer provider if nil.
 
 	// clients.
 	ssmsvc iSSMClient
+	stssvc iSTSClient // Used to determine the AWS account used; nil if unknown.
 
 	// aws.
 	awsRegion      string // The aws region to use when doing things with paramstore.
+	awsProfile     string // The aws profile to use when loading the aws config.
 	batchSize      int    // The batch size used when retrieving parameters.
 	withDecryption bool   // This decrypts parameters when retrieving them.
 	keyId          string // The KMS key to use when encrypting and decrypting parameters from paramstore.
 
+	// naming.
+	prefix        string            // The prefix added to every parameter name used by this client.
+	nameTemplate  string            // The template every parameter name used by this client follows.
+	nameVariables map[string]string // The variables replaced in every parameter name used by this client.
+
+	// values.
+	largeValues bool        // If true, values too large for their tier are split into chunks.
+	codecs      []codecRule // The codecs used to encode values, by prefix.
+	encrypter   Encrypter   // If set, values are encrypted client-side before they're uploaded.
+
+	requireEncryption bool // If true, values retrieved without being encrypted client-side are rejected.
+
+	// safety.
+	dryRun         bool     // If true, mutating operations are logged and classified, but not made.
+	idempotentPuts bool     // If true, Put() only uploads params that differ from their current value.
+	protected      []string // The (qualified) prefixes DeletePath() refuses to delete params under.
+	trash          Trash    // If set, Delete() moves each param here before deleting it.
+
+	// output.
+	redaction RedactionPolicy // How SecureString values retrieved by this client should be redacted in output.
+
 	// misc.
 	logLevel slog.Level   // The log level of the default logger.
 	logger   *slog.Logger // The logger used in this client (custom or default).
@@ -55,14 +126,9 @@ type Client struct {
 // parameters in AWS SSM Parameter Store.
 func New(ctx context.Context, options ...Option) (*Client, error) {
 
-	// setup tracing.
-	tracerName := "paramstore"
-	newCtx, span := otel.Tracer(tracerName).Start(ctx, "New")
-	defer span.End()
-
 	// setup client w/ default values.
 	c := &Client{
-		tracerName: tracerName,
+		tracerName: "paramstore",
 
 		awsRegion:      "ap-southeast-2",
 		batchSize:      10,
@@ -76,6 +142,12 @@ func New(ctx context.Context, options ...Option) (*Client, error) {
 		}
 	}
 
+	// setup tracing.
+	// NOTE: this happens after the options are set, so the tracer provider
+	// given to WithTracerProvider is used.
+	newCtx, span := c.startSpan(ctx, "New")
+	defer span.End()
+
 	// determine if the default logger should be used.
 	if c.logger == nil {
 
@@ -92,18 +164,61 @@ func New(ctx context.Context, options ...Option) (*Client, error) {
 
 	}
 
-	// load aws config.
-	cfg, err := config.LoadDefaultConfig(newCtx)
-	if err != nil {
-		return nil, ErrClientFailedToLoadAWSConfig{err}
+	// use the mock store in the given context, if any, instead of AWS.
+	// NOTE: this is only set by the tests of the commands in this module.
+	if s, ok := mockssm.FromContext(ctx); ok {
+		c.ssmsvc = s.Client(func() any {
+			mock, _ := newMockSSMStore(nil)
+			return mock
+		}).(iSSMClient)
 	}
 
-	// setup ssm client.
-	c.ssmsvc = ssm.New(ssm.Options{
-		Region:      c.awsRegion,
-		Credentials: cfg.Credentials,
-	})
+	// load aws config.
+	if c.ssmsvc == nil {
+		var loadOptions []func(*config.LoadOptions) error
+		if c.awsProfile != "" {
+			loadOptions = append(loadOptions, config.WithSharedConfigProfile(c.awsProfile))
+		}
+		cfg, err := config.LoadDefaultConfig(newCtx, loadOptions...)
+		if err != nil {
+			return nil, ErrClientFailedToLoadAWSConfig{err}
+		}
+
+		// setup ssm client.
+		c.ssmsvc = ssm.New(ssm.Options{
+			Region:      c.awsRegion,
+			Credentials: cfg.Credentials,
+		})
+
+		// setup sts client.
+		c.stssvc = sts.New(sts.Options{
+			Region:      c.awsRegion,
+			Credentials: cfg.Credentials,
+		})
+	}
 
 	c.logger.Debug("client setup successfully")
 	return c, nil
 }
+
+// RedactionPolicy returns the policy configured for this client with
+// WithRedactionPolicy, to pass to Parameters.Encode and Plan.Diff.
+func (c *Client) RedactionPolicy() RedactionPolicy {
+	if c.redaction == "" {
+		return RedactionMask
+	}
+	return c.redaction
+}
+
+// Sub creates and returns a child Client, scoped to the given prefix. The
+// prefix is appended to any prefix already configured for this client, and
+// the child shares the same configuration, logger and clients as its parent.
+func (c *Client) Sub(prefix string) (*Client, error) {
+	prefix, err := cleanPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	child := *c
+	child.prefix = c.prefix + prefix
+	return &child, nil
+}
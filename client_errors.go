@@ -22,3 +22,13 @@ type ErrClientFailedToLoadAWSConfig struct {
 func (e ErrClientFailedToLoadAWSConfig) Error() string {
 	return fmt.Sprintf("failed to load AWS config: %v", e.err)
 }
+
+// ErrInvalidParameter is returned when AWS SSM Parameter Store reports that a
+// parameter is invalid, which usually means the parameter doesn't exist.
+type ErrInvalidParameter struct {
+	Name string
+}
+
+func (e ErrInvalidParameter) Error() string {
+	return fmt.Sprintf("%q is an invalid parameter", e.Name)
+}
@@ -1,8 +1,12 @@
 package paramstore
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Option configures a paramstore client.
@@ -24,6 +28,18 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
+// WithTracerProvider configures the tracer provider used to create the spans
+// in the client, rather than the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) error {
+		if tp == nil {
+			return errors.New("tracerProvider cannot be nil")
+		}
+		c.tracerProvider = tp
+		return nil
+	}
+}
+
 // WithDecryption configures the decryption used by the client when retrieving
 // from AWS SSM Parameter Store. This option must be given to decrypt any
 // parameters returned to this client.
@@ -34,6 +50,145 @@ func WithDecryption(decryption bool) Option {
 	}
 }
 
+// WithLargeValues configures Put(), PutIfVersion(), PutIfAbsent() and
+// Transaction() to split any value too large for the tier of its param into
+// chunks, stored as child params under "<name>/_chunks", with a manifest
+// (including a checksum) stored as the value of the param itself. Get(),
+// GetMultiple() and GetByPath() reassemble these values, and Delete() removes
+// their chunks.
+func WithLargeValues(largeValues bool) Option {
+	return func(c *Client) error {
+		c.largeValues = largeValues
+		return nil
+	}
+}
+
+// WithCodec configures the codec used by Put() to encode the value of every
+// param under the given prefix, unless the param sets its own codec; "/"
+// matches every param. The longest matching prefix wins, so CodecPlain can be
+// used to exclude params under a longer prefix. Encoded values are decoded
+// automatically by Get(), GetMultiple() and GetByPath(), regardless of the
+// codecs configured.
+func WithCodec(prefix string, codec Codec) Option {
+	return func(c *Client) error {
+		if _, err := ParseCodec(string(codec)); err != nil {
+			return err
+		}
+		p, err := cleanPrefix(prefix)
+		if err != nil {
+			return err
+		}
+		c.codecs = append(c.codecs, codecRule{p, codec})
+		return nil
+	}
+}
+
+// WithEncrypter configures the client to encrypt every value with the given
+// encrypter before it's uploaded, after it's encoded, and to decrypt values
+// when they're retrieved. See NewAESGCMEncrypter and NewEnvelopeEncrypter.
+func WithEncrypter(e Encrypter) Option {
+	return func(c *Client) error {
+		c.encrypter = e
+		return nil
+	}
+}
+
+// WithRequireEncryption configures the client to reject any value retrieved
+// without being encrypted by the encrypter given to WithEncrypter, so a value
+// can't be replaced with plaintext by anyone allowed to put the param.
+func WithRequireEncryption(require bool) Option {
+	return func(c *Client) error {
+		c.requireEncryption = require
+		return nil
+	}
+}
+
+// WithRedactionPolicy configures how SecureString values retrieved by this
+// client should be redacted when they're encoded, or diffed, without being
+// revealed; RedactionMask is used by default. See RedactionPolicy().
+// NOTE: unsalted hashes of short or guessable values can be brute forced, so
+// RedactionHash should only be used where the hashes themselves aren't public.
+func WithRedactionPolicy(policy RedactionPolicy) Option {
+	return func(c *Client) error {
+		p, err := ParseRedactionPolicy(string(policy))
+		if err != nil {
+			return err
+		}
+		c.redaction = p
+		return nil
+	}
+}
+
+// WithDryRun configures the client to only log and classify the changes that
+// mutating operations (eg. Put, Delete) would make, without making them. This
+// can be overridden for a single call with ContextWithDryRun.
+func WithDryRun(dryRun bool) Option {
+	return func(c *Client) error {
+		c.dryRun = dryRun
+		return nil
+	}
+}
+
+// WithIdempotentPut configures Put() to retrieve the current value and
+// metadata of each param first, and only upload the params that have changed,
+// so unchanged params don't get a new version.
+func WithIdempotentPut(idempotent bool) Option {
+	return func(c *Client) error {
+		c.idempotentPuts = idempotent
+		return nil
+	}
+}
+
+// WithProtectedPrefixes configures prefixes, such as "/prod", that
+// DeletePath() refuses to delete any param under. The prefixes are matched
+// against full names, including any prefix configured via WithPrefix; "/"
+// protects every param. Prefixes are merged with any prefixes already configured.
+func WithProtectedPrefixes(prefixes ...string) Option {
+	return func(c *Client) error {
+		for _, prefix := range prefixes {
+			if prefix == "" {
+				return ErrInvalidPrefix{prefix, "prefix cannot be empty"}
+			}
+			p, err := cleanPrefix(prefix)
+			if err != nil {
+				return err
+			}
+			c.protected = append(c.protected, p)
+		}
+		return nil
+	}
+}
+
+// WithTrash configures Delete() to soft-delete params, by moving the latest
+// value and metadata of each param to the given trash before deleting it, so
+// it can be restored with Restore(). A nil trash turns soft-delete off.
+func WithTrash(trash Trash) Option {
+	return func(c *Client) error {
+		c.trash = trash
+		return nil
+	}
+}
+
+// WithTrashPrefix configures Delete() to soft-delete params, in the same way
+// as WithTrash, using a trash that stores each param under the given prefix in
+// paramstore, such as "/trash/myapp/prod/db/host".
+func WithTrashPrefix(prefix string) Option {
+	return func(c *Client) error {
+		if prefix == "" {
+			return ErrInvalidPrefix{prefix, "prefix cannot be empty"}
+		}
+		p, err := cleanPrefix(prefix)
+		if err != nil {
+			return err
+		}
+		if p == "" {
+			return ErrInvalidPrefix{prefix, "prefix cannot be \"/\""}
+		}
+		c.trash = &paramstoreTrash{c, p}
+		return nil
+	}
+}
+
 // WithAWSRegion configures the AWS region used in the client.
 func WithAWSRegion(region string) Option {
 	return func(c *Client) error {
@@ -42,6 +197,15 @@ func WithAWSRegion(region string) Option {
 	}
 }
 
+// WithAWSProfile configures the AWS profile used to load the AWS config used
+// in the client, from the shared config and credentials files.
+func WithAWSProfile(profile string) Option {
+	return func(c *Client) error {
+		c.awsProfile = profile
+		return nil
+	}
+}
+
 const (
 	// the min batch size used when uploading to paramstore.
 	minBatchSize = 0
@@ -64,3 +228,48 @@ func WithBatchSize(size int) Option {
 		return nil
 	}
 }
+
+// WithPrefix configures a prefix that is transparently added to every
+// parameter name given to the client, and removed from every parameter name
+// returned by the client. Names that would escape the prefix, via ".." or an
+// ARN, are rejected.
+func WithPrefix(prefix string) Option {
+	return func(c *Client) error {
+		p, err := cleanPrefix(prefix)
+		if err != nil {
+			return err
+		}
+		c.prefix = p
+		return nil
+	}
+}
+
+// WithNameTemplate configures a template that every parameter name given to
+// the client follows, such as "/{org}/{env}/{service}/{key}". The "{key}"
+// placeholder is replaced by the name given to the client, while any other
+// placeholders are replaced by the variables given via WithNameVariables. The
+// template is removed from every parameter name returned by the client.
+func WithNameTemplate(template string) Option {
+	return func(c *Client) error {
+		if !strings.Contains(template, nameTemplateKey) {
+			return fmt.Errorf("nameTemplate must contain %v", nameTemplateKey)
+		}
+		c.nameTemplate = template
+		return nil
+	}
+}
+
+// WithNameVariables configures the variables replaced in every parameter name
+// given to the client, such as "env" for the "{env}" placeholder. Variables
+// are merged with any variables already configured.
+func WithNameVariables(variables map[string]string) Option {
+	return func(c *Client) error {
+		if c.nameVariables == nil {
+			c.nameVariables = make(map[string]string, len(variables))
+		}
+		for k, v := range variables {
+			c.nameVariables[k] = v
+		}
+		return nil
+	}
+}
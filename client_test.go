@@ -122,6 +122,36 @@ func Test_New(t *testing.T) {
 			},
 			err: "batchSize must be less than or equal to 10",
 		},
+		"with prefix": {
+			options: []Option{WithPrefix("/myapp/prod/")},
+			want: &Client{
+				awsRegion:      "ap-southeast-2",
+				batchSize:      10,
+				withDecryption: false,
+				logger:         slog.Default(),
+				prefix:         "/myapp/prod",
+			},
+		},
+		"with prefix (escaping)": {
+			options: []Option{WithPrefix("/myapp/../prod")},
+			err:     `invalid prefix "/myapp/../prod"`,
+		},
+		"with name template": {
+			options: []Option{
+				WithNameTemplate("/{env}/{key}"),
+				WithNameVariables(map[string]string{"env": "prod"}),
+			},
+			want: &Client{
+				awsRegion:      "ap-southeast-2",
+				batchSize:      10,
+				withDecryption: false,
+				logger:         slog.Default(),
+			},
+		},
+		"with name template (missing key)": {
+			options: []Option{WithNameTemplate("/{env}/db")},
+			err:     "nameTemplate must contain {key}",
+		},
 		"with decryption": {
 			options: []Option{WithDecryption(true)},
 			want: &Client{
@@ -131,6 +161,44 @@ func Test_New(t *testing.T) {
 				logger:         slog.Default(),
 			},
 		},
+		"with dry run": {
+			options: []Option{WithDryRun(true)},
+			want: &Client{
+				awsRegion:      "ap-southeast-2",
+				batchSize:      10,
+				withDecryption: false,
+				logger:         slog.Default(),
+				dryRun:         true,
+			},
+		},
+		"with idempotent put": {
+			options: []Option{WithIdempotentPut(true)},
+			want: &Client{
+				awsRegion:      "ap-southeast-2",
+				batchSize:      10,
+				withDecryption: false,
+				logger:         slog.Default(),
+				idempotentPuts: true,
+			},
+		},
+		"with protected prefixes": {
+			options: []Option{WithProtectedPrefixes("/myapp/prod/", "/shared")},
+			want: &Client{
+				awsRegion:      "ap-southeast-2",
+				batchSize:      10,
+				withDecryption: false,
+				logger:         slog.Default(),
+				protected:      []string{"/myapp/prod", "/shared"},
+			},
+		},
+		"with trash prefix (root)": {
+			options: []Option{WithTrashPrefix("/")},
+			err:     `invalid prefix "/": prefix cannot be "/"`,
+		},
+		"with protected prefixes (empty)": {
+			options: []Option{WithProtectedPrefixes("")},
+			err:     `invalid prefix ""`,
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -151,7 +219,11 @@ func Test_New(t *testing.T) {
 				(got.logger != slog.Default() && tt.want.logger != slog.Default()) && got.logger != tt.want.logger,
 				got.awsRegion != tt.want.awsRegion,
 				got.withDecryption != tt.want.withDecryption,
-				got.batchSize != tt.want.batchSize:
+				got.batchSize != tt.want.batchSize,
+				got.prefix != tt.want.prefix,
+				got.dryRun != tt.want.dryRun,
+				got.idempotentPuts != tt.want.idempotentPuts,
+				strings.Join(got.protected, ",") != strings.Join(tt.want.protected, ","):
 				t.Errorf(
 					"New() returned unexpected configuration; want=%+v, got=%+v\n",
 					tt.want,
@@ -162,3 +234,54 @@ func Test_New(t *testing.T) {
 		})
 	}
 }
+
+func Test_Sub(t *testing.T) {
+	tests := map[string]struct {
+		client *Client
+		prefix string
+		want   string
+		err    string
+	}{
+		"sub client": {
+			client: &Client{},
+			prefix: "/myapp",
+			want:   "/myapp",
+		},
+		"sub client of prefixed client": {
+			client: &Client{prefix: "/myapp"},
+			prefix: "/prod/",
+			want:   "/myapp/prod",
+		},
+		"catch relative prefix": {
+			client: &Client{prefix: "/myapp"},
+			prefix: "prod",
+			err:    `invalid prefix "prod": prefix must start with "/"`,
+		},
+		"catch escaping prefix": {
+			client: &Client{prefix: "/myapp"},
+			prefix: "/../other",
+			err:    `invalid prefix "/../other": prefix cannot contain ".."`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.client.Sub(tt.prefix)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Sub() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Sub() returned an error; error=%v", err)
+				return
+			}
+			if got.prefix != tt.want {
+				t.Errorf("Sub() returned unexpected prefix; want=%v, got=%v", tt.want, got.prefix)
+			}
+			if tt.client.prefix == got.prefix && tt.prefix != "" {
+				t.Errorf("Sub() modified the parent client")
+			}
+		})
+	}
+}
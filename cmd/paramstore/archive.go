@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+// runExport writes every parameter under a path to an archive.
+func runExport(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("export")
+	output := fs.String("out", "", "The file to write the archive to; defaults to stdout.")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	if *output == "" {
+		return h.paramstoresvc.Export(ctx, fs.Arg(0), h.stdout)
+	}
+
+	// write archive.
+	// NOTE: the archive contains decrypted SecureString values, so it's only
+	// readable by the current user.
+	f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	if err := h.paramstoresvc.Export(ctx, fs.Arg(0), f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// runImport restores every parameter in an archive.
+func runImport(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("import")
+	conflict := fs.String("conflict", string(paramstore.ConflictFail), "What to do with parameters that already exist (fail, skip or overwrite).")
+	path := fs.String("path", "", "Restore the parameters under this path, rather than the path they were exported from.")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	// read archive.
+	var r io.Reader = h.stdin
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	// restore parameters.
+	result, err := h.paramstoresvc.Import(ctx, r, paramstore.ImportOptions{
+		Conflict: paramstore.ConflictPolicy(*conflict),
+		Path:     *path,
+	})
+	for _, n := range result.Imported {
+		fmt.Fprintf(h.stdout, "imported %v\n", n)
+	}
+	for _, n := range result.Skipped {
+		fmt.Fprintf(h.stdout, "skipped %v\n", n)
+	}
+	return err
+}
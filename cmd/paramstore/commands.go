@@ -0,0 +1,510 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+// flags returns a new flag set for the given command.
+func (h *handler) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(h.name+" "+name, flag.ContinueOnError)
+	fs.SetOutput(h.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(h.stderr, "usage: %v %v %v\n", h.name, name, commands[name].usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses the given args with the given flag set, checking the number of
+// positional args left afterwards is between min and max (-1 for no max).
+func parse(fs *flag.FlagSet, args []string, min, max int) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage{err.Error()}
+	}
+	if fs.NArg() < min || (max >= 0 && fs.NArg() > max) {
+		fs.Usage()
+		return usageErrorf("unexpected number of arguments")
+	}
+	return nil
+}
+
+// encode writes the given parameters to stdout, in the given format.
+func (h *handler) encode(params paramstore.Parameters, format string, reveal bool) error {
+	f, err := paramstore.ParseFormat(format)
+	if err != nil {
+		return errUsage{err.Error()}
+	}
+	return params.Encode(h.stdout, f, paramstore.EncodeOptions{
+		Reveal:    reveal,
+		Redaction: h.paramstoresvc.RedactionPolicy(),
+	})
+}
+
+// client returns a new client, configured in the same way as paramstoresvc
+// but for the given region and profile, if set.
+func (h *handler) client(ctx context.Context, region, profile string) (*paramstore.Client, error) {
+	options := append([]paramstore.Option{}, h.options...)
+	if region != "" {
+		options = append(options, paramstore.WithAWSRegion(region))
+	}
+	if profile != "" {
+		options = append(options, paramstore.WithAWSProfile(profile))
+	}
+	return paramstore.New(ctx, options...)
+}
+
+// formats returns every output format, as a comma-separated string.
+func formats() string {
+	out := make([]string, len(paramstore.Formats))
+	for i, f := range paramstore.Formats {
+		out[i] = string(f)
+	}
+	return strings.Join(out, ", ")
+}
+
+// runGet prints the value of one or more parameters.
+func runGet(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("get")
+	version := fs.Int64("version", 0, "The version of the parameter to get.")
+	label := fs.String("label", "", "The label of the parameter version to get.")
+	output := fs.String("o", "", "The output format ("+formats()+").")
+	reveal := fs.Bool("reveal", false, "Don't redact SecureString values in the output format.")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	if *version > 0 && *label != "" {
+		return usageErrorf("-version and -label cannot be used together")
+	}
+
+	// determine names, with selectors.
+	names := fs.Args()
+	for i := range names {
+		switch {
+		case *version > 0:
+			names[i] += ":" + strconv.FormatInt(*version, 10)
+		case *label != "":
+			names[i] += ":" + *label
+		}
+	}
+
+	// retrieve parameters.
+	params, err := h.paramstoresvc.GetMultiple(ctx, names...)
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		return h.encode(params, *output, *reveal)
+	}
+	if len(params) == 1 {
+		fmt.Fprintln(h.stdout, params[0].Value)
+		return nil
+	}
+	for _, p := range params {
+		fmt.Fprintf(h.stdout, "%v=%v\n", p.Name, p.Value)
+	}
+	return nil
+}
+
+// runPut uploads a parameter.
+func runPut(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("put")
+	t := fs.String("type", string(paramstore.ParameterTypeString), "The type of the parameter (String, StringList or SecureString).")
+	tier := fs.String("tier", "", "The tier of the parameter (Standard, Advanced or Intelligent-Tiering).")
+	overwrite := fs.Bool("overwrite", false, "Overwrite the parameter, if it already exists.")
+	ifVersion := fs.Int64("if-version", -1, "Only upload the parameter if its latest version is this version; 0 if it mustn't exist.")
+	codec := fs.String("codec", "", "Encode the value with this codec (plain, base64, gzip or zstd).")
+	ifAbsent := fs.Bool("if-absent", false, "Only upload the parameter if it doesn't exist, leaving it unchanged otherwise.")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	if *ifAbsent && *ifVersion >= 0 {
+		return usageErrorf("-if-absent and -if-version cannot be used together")
+	}
+	if *codec != "" {
+		c, err := paramstore.ParseCodec(*codec)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+		*codec = string(c)
+	}
+
+	// determine value.
+	value := fs.Arg(1)
+	if value == "-" {
+		b, err := io.ReadAll(h.stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read value from stdin: %w", err)
+		}
+		value = strings.TrimRight(string(b), "\n")
+	}
+
+	// upload parameter.
+	p := paramstore.Parameter{
+		Name:      fs.Arg(0),
+		Value:     value,
+		Type:      paramstore.ParameterType(*t),
+		Tier:      paramstore.ParameterTier(*tier),
+		Overwrite: *overwrite,
+		Codec:     paramstore.Codec(*codec),
+	}
+	switch {
+	case *ifVersion >= 0:
+		version, err := h.paramstoresvc.PutIfVersion(ctx, p, *ifVersion)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(h.stdout, version)
+		return nil
+
+	case *ifAbsent:
+		created, err := h.paramstoresvc.PutIfAbsent(ctx, p)
+		if err == nil && !created {
+			fmt.Fprintf(h.stderr, "%v already exists, leaving it unchanged\n", p.Name)
+		}
+		return err
+	}
+	outcomes, err := h.paramstoresvc.PutWithOutcomes(ctx, paramstore.Parameters{p})
+	h.printOutcomes(outcomes)
+	return err
+}
+
+// runRm deletes one or more parameters.
+func runRm(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("rm")
+	recursive := fs.Bool("r", false, "Delete every parameter under PATH.")
+	confirm := fs.String("confirm", "", "With -r, confirm the deletion by giving PATH again.")
+	force := fs.Bool("force", false, "With -r, delete without confirmation.")
+	max := fs.Int("max", 0, "With -r, delete nothing if more than this many parameters are found.")
+	export := fs.String("export", "", "With -r, write the parameters to this archive before deleting them.")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	if !*recursive {
+		outcomes, err := h.paramstoresvc.DeleteWithOutcomes(ctx, fs.Args()...)
+		h.printOutcomes(outcomes)
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("-r takes a single PATH")
+	}
+	opts := paramstore.DeletePathOptions{
+		Confirm:  *confirm,
+		Force:    *force,
+		MaxCount: *max,
+	}
+
+	// write archive.
+	// NOTE: the archive contains decrypted SecureString values, so it's only
+	// readable by the current user.
+	if *export != "" {
+		f, err := os.OpenFile(*export, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create archive: %w", err)
+		}
+		defer f.Close()
+		opts.Export = f
+	}
+	outcomes, err := h.paramstoresvc.DeletePath(ctx, fs.Arg(0), opts)
+	h.printOutcomes(outcomes)
+	return err
+}
+
+// printOutcomes prints the change that would be made to each parameter, in
+// dry-run mode.
+func (h *handler) printOutcomes(outcomes paramstore.Outcomes) {
+	if !h.dryRun {
+		return
+	}
+	for _, o := range outcomes {
+		if o.Err == nil {
+			fmt.Fprintf(h.stdout, "%-7v %v\n", o.Action, o.Name)
+		}
+	}
+}
+
+// runLs lists the parameters under a path.
+func runLs(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("ls")
+	recursive := fs.Bool("r", false, "List parameters nested deeper than one level below the path.")
+	long := fs.Bool("l", false, "List the type, version and last modified date of each parameter.")
+	output := fs.String("o", "", "The output format ("+formats()+"), including values.")
+	reveal := fs.Bool("reveal", false, "Don't redact SecureString values in the output format.")
+	if err := parse(fs, args, 0, 1); err != nil {
+		return err
+	}
+
+	// retrieve parameters.
+	params, err := h.paramstoresvc.GetByPath(ctx, fs.Arg(0), *recursive)
+	if err != nil {
+		return err
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+
+	// print parameters.
+	if *output != "" {
+		return h.encode(params, *output, *reveal)
+	}
+	if !*long {
+		for _, p := range params {
+			fmt.Fprintln(h.stdout, p.Name)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(h.stdout, 0, 0, 2, ' ', 0)
+	for _, p := range params {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", p.Type, p.Version, p.LastModifiedDate.Format(time.RFC3339), p.Name)
+	}
+	return w.Flush()
+}
+
+// runTree prints the parameters under a path as a tree.
+func runTree(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("tree")
+	if err := parse(fs, args, 0, 1); err != nil {
+		return err
+	}
+	root := fs.Arg(0)
+	if root == "" {
+		root = "/"
+	}
+
+	// retrieve parameters.
+	params, err := h.paramstoresvc.GetByPath(ctx, root, true)
+	if err != nil {
+		return err
+	}
+
+	// build tree.
+	t := node{}
+	base := strings.TrimRight(root, "/") + "/"
+	for _, p := range params {
+		n := t
+		for _, part := range strings.Split(strings.TrimPrefix(p.Name, base), "/") {
+			if n[part] == nil {
+				n[part] = node{}
+			}
+			n = n[part]
+		}
+	}
+
+	// print tree.
+	fmt.Fprintln(h.stdout, root)
+	t.print(h.stdout, "")
+	return nil
+}
+
+// node is a single level of a tree of parameter names.
+type node map[string]node
+
+// print prints each child of this node, and their children, with the given
+// indent.
+func (n node) print(w io.Writer, indent string) {
+	names := make([]string, 0, len(n))
+	for name := range n {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		branch, next := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%v%v%v\n", indent, branch, name)
+		n[name].print(w, indent+next)
+	}
+}
+
+// runHistory prints every version of a parameter.
+func runHistory(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("history")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	// retrieve history.
+	history, err := h.paramstoresvc.History(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// print history.
+	w := tabwriter.NewWriter(h.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tMODIFIED\tTYPE\tLABELS\tVALUE")
+	for _, v := range history {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
+			v.Version,
+			v.LastModifiedDate.Format(time.RFC3339),
+			v.Type,
+			strings.Join(v.Labels, ","),
+			v.Value,
+		)
+	}
+	return w.Flush()
+}
+
+// runLabel attaches labels to a version of a parameter.
+func runLabel(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("label")
+	version := fs.Int64("version", 0, "The version of the parameter to label; defaults to the latest version.")
+	if err := parse(fs, args, 2, -1); err != nil {
+		return err
+	}
+	return h.paramstoresvc.Label(ctx, fs.Arg(0), *version, fs.Args()[1:]...)
+}
+
+// runTag lists, adds or removes the tags on a parameter.
+func runTag(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("tag")
+	remove := fs.Bool("d", false, "Remove the given tag keys.")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	name, rest := fs.Arg(0), fs.Args()[1:]
+
+	switch {
+	case *remove:
+		if len(rest) == 0 {
+			return usageErrorf("no tag keys given to remove")
+		}
+		return h.paramstoresvc.Untag(ctx, name, rest...)
+
+	case len(rest) > 0:
+		tags := make(paramstore.Tags, len(rest))
+		for _, r := range rest {
+			k, v, ok := strings.Cut(r, "=")
+			if !ok || k == "" {
+				return usageErrorf("tags must be given as KEY=VALUE; got %q", r)
+			}
+			tags[k] = v
+		}
+		return h.paramstoresvc.Tag(ctx, name, tags)
+	}
+
+	// list tags.
+	tags, err := h.paramstoresvc.Tags(ctx, name)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h.stdout, "%v=%v\n", k, tags[k])
+	}
+	return nil
+}
+
+// runCp copies a parameter, or every parameter under a path.
+func runCp(ctx context.Context, h *handler, args []string) error {
+	return copyParameters(ctx, h, "cp", args)
+}
+
+// runMv moves a parameter, or every parameter under a path.
+func runMv(ctx context.Context, h *handler, args []string) error {
+	return copyParameters(ctx, h, "mv", args)
+}
+
+// copyParameters copies or moves a parameter, or every parameter under a path,
+// for the given command, printing the outcome for each parameter.
+func copyParameters(ctx context.Context, h *handler, name string, args []string) error {
+	fs := h.flags(name)
+	recursive := fs.Bool("r", false, "Copy every parameter under the SRC path.")
+	overwrite := fs.Bool("overwrite", false, "Overwrite parameters that already exist at DST.")
+	dryRun := fs.Bool("dry-run", false, "Print what would be copied, without changing anything.")
+	keyId := fs.String("kms-key", "", "Re-encrypt SecureString parameters at DST with this KMS key.")
+	region := fs.String("dest-region", "", "The AWS region of DST; defaults to the region of SRC.")
+	profile := fs.String("dest-profile", "", "The AWS profile used for DST; defaults to the profile used for SRC.")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	opts := paramstore.CopyOptions{
+		Recursive: *recursive,
+		Overwrite: *overwrite,
+		KeyId:     *keyId,
+		DryRun:    *dryRun,
+	}
+
+	// setup destination client.
+	if *region != "" || *profile != "" {
+		dst, err := h.client(ctx, *region, *profile)
+		if err != nil {
+			return err
+		}
+		opts.Destination = dst
+	}
+
+	// copy parameters.
+	run := h.paramstoresvc.Copy
+	if name == "mv" {
+		run = h.paramstoresvc.Move
+	}
+	results, err := run(ctx, fs.Arg(0), fs.Arg(1), opts)
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			fmt.Fprintf(h.stdout, "failed %v -> %v: %v\n", r.Source, r.Destination, r.Err)
+		case *dryRun:
+			fmt.Fprintf(h.stdout, "would %v %v -> %v\n", name, r.Source, r.Destination)
+		default:
+			fmt.Fprintf(h.stdout, "%v %v -> %v\n", name, r.Source, r.Destination)
+		}
+	}
+	return err
+}
+
+// runDiff prints the differences between the parameters under two paths.
+func runDiff(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("diff")
+	region := fs.String("right-region", "", "The AWS region of RIGHT; defaults to the region of LEFT.")
+	profile := fs.String("right-profile", "", "The AWS profile used for RIGHT; defaults to the profile used for LEFT.")
+	exitCode := fs.Bool("exit-code", false, "Exit with 1 if there are any differences.")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+
+	// setup right client.
+	right := h.paramstoresvc
+	if *region != "" || *profile != "" {
+		var err error
+		if right, err = h.client(ctx, *region, *profile); err != nil {
+			return err
+		}
+	}
+
+	// compare parameters.
+	diffs, err := paramstore.Diff(ctx,
+		paramstore.Location{Client: h.paramstoresvc, Path: fs.Arg(0)},
+		paramstore.Location{Client: right, Path: fs.Arg(1)},
+	)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(h.stdout, 0, 0, 2, ' ', 0)
+	for _, d := range diffs {
+		detail := strings.Join(d.Fields, ",")
+		if d.Kind == paramstore.DriftKindValueMismatch {
+			detail = fmt.Sprintf("%q != %q", d.Left, d.Right)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\n", d.Kind, d.Name, detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if *exitCode && len(diffs) > 0 {
+		return errExit{exitError}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+// loadEncrypter reads the given keys, each as ID=FILE where FILE contains a
+// base64 encoded AES key, returning an encrypter that uses the first key to
+// encrypt new values.
+func loadEncrypter(keys []string) (paramstore.Encrypter, error) {
+	var current string
+	loaded := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		id, path, ok := strings.Cut(k, "=")
+		if !ok || id == "" || path == "" {
+			return nil, usageErrorf("-encryption-key must be ID=FILE; got %q", k)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key %q: %w", id, err)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode encryption key %q: %w", id, err)
+		}
+		if current == "" {
+			current = id
+		}
+		loaded[id] = key
+	}
+	return paramstore.NewAESGCMEncrypter(current, loaded)
+}
+
+// runReencrypt re-encrypts every parameter under a path that was encrypted
+// with an older key.
+func runReencrypt(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("reencrypt")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	outcomes, err := h.paramstoresvc.Reencrypt(ctx, fs.Arg(0))
+	h.printOutcomes(outcomes)
+	return err
+}
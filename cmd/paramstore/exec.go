@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+// the signals forwarded to a child process.
+var forwardedSignals = []os.Signal{
+	os.Interrupt,
+	syscall.SIGTERM,
+	syscall.SIGHUP,
+	syscall.SIGQUIT,
+}
+
+// stringsFlag is a flag that can be given more than once.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runExec loads the parameters under one or more paths into the environment
+// of a child process, then runs it.
+func runExec(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("exec")
+	var paths stringsFlag
+	fs.Var(&paths, "path", "A path to load parameters from; can be given more than once, later paths override earlier paths.")
+	envCase := fs.String("case", string(paramstore.EnvCaseUpper), "The case of environment variable keys (upper, lower or preserve).")
+	separator := fs.String("separator", "_", "Replaces every character in a key that isn't a letter or number.")
+	keepPath := fs.Bool("keep-path", false, "Keep the path a parameter was loaded from in its key.")
+	override := fs.Bool("override", true, "Override existing environment variables with the same key.")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return usageErrorf("at least one -path is required")
+	}
+
+	// load environment.
+	vars, err := h.paramstoresvc.Env(ctx, paths, paramstore.EnvOptions{
+		Case:      paramstore.EnvCase(*envCase),
+		Separator: *separator,
+		KeepPath:  *keepPath,
+	})
+	if err != nil {
+		return err
+	}
+	return h.exec(fs.Args(), paramstore.MergeEnv(os.Environ(), vars, *override))
+}
+
+// exec runs the given command with the given environment, forwarding signals
+// to it and returning its exit code as an errExit.
+func (h *handler) exec(args []string, environ []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = environ
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = h.stdin, h.stdout, h.stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// forward signals.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+	go func() {
+		for s := range signals {
+			_ = cmd.Process.Signal(s)
+		}
+	}()
+
+	// wait for the child to exit.
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			code = 128 + int(status.Signal())
+		}
+		return errExit{code}
+	}
+	return err
+}
+
+// runResolve resolves every reference to a parameter in the environment of
+// this process, then either runs a child process with the resolved
+// environment, or prints the resolved variables.
+func runResolve(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("resolve")
+	output := fs.String("o", string(paramstore.FormatShell), "The output format, when no command is given (dotenv or shell).")
+	if err := parse(fs, args, 0, -1); err != nil {
+		return err
+	}
+
+	// resolve environment.
+	environ, err := h.paramstoresvc.ResolveEnviron(ctx, os.Environ())
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return h.exec(fs.Args(), environ)
+	}
+
+	// print resolved variables.
+	f, err := paramstore.ParseFormat(*output)
+	if err != nil || (f != paramstore.FormatShell && f != paramstore.FormatDotenv) {
+		return usageErrorf("unsupported output format %q", *output)
+	}
+	var vars paramstore.Parameters
+	for _, e := range os.Environ() {
+		k, v, _ := strings.Cut(e, "=")
+		if strings.HasPrefix(v, paramstore.ReferencePrefix) {
+			vars = append(vars, paramstore.Parameter{Name: k})
+		}
+	}
+	resolved := make(map[string]string, len(environ))
+	for _, e := range environ {
+		k, v, _ := strings.Cut(e, "=")
+		resolved[k] = v
+	}
+	for i := range vars {
+		vars[i].Value = resolved[vars[i].Name]
+	}
+	return vars.Encode(h.stdout, f, paramstore.EncodeOptions{
+		Reveal:  true,
+		KeyFunc: func(name string) string { return name },
+	})
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+// the exit codes used by this binary, one per class of error.
+const (
+	exitOK           = 0 // The command succeeded.
+	exitError        = 1 // The command failed for an unclassified reason.
+	exitUsage        = 2 // The command was used incorrectly.
+	exitNotFound     = 3 // A parameter doesn't exist.
+	exitInvalid      = 4 // A parameter, name or value is invalid.
+	exitConflict     = 5 // A parameter already exists, or was changed concurrently.
+	exitAccessDenied = 6 // The AWS credentials used aren't allowed to do this.
+	exitSetup        = 7 // The client couldn't be configured.
+)
+
+// errUsage is returned when a command is used incorrectly.
+type errUsage struct {
+	msg string
+}
+
+func (e errUsage) Error() string {
+	return e.msg
+}
+
+// usageErrorf returns a new errUsage, with a formatted message.
+func usageErrorf(format string, a ...any) error {
+	return errUsage{fmt.Sprintf(format, a...)}
+}
+
+// errExit is returned when a child process exits with a non-zero exit code,
+// which is passed through as the exit code of this binary.
+type errExit struct {
+	code int
+}
+
+func (e errExit) Error() string {
+	return fmt.Sprintf("exit status %v", e.code)
+}
+
+// exitCode determines the exit code for the given error.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	// check errors returned by this binary and the paramstore package.
+	var exitErr errExit
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.As(err, &errUsage{}):
+		return exitUsage
+	case
+		errors.As(err, &paramstore.ErrInvalidParameter{}),
+		errors.As(err, &paramstore.ErrNotInTrash{}),
+		errors.As(err, &paramstore.ErrUnresolvedReferences{}):
+		return exitNotFound
+	case
+		errors.As(err, &paramstore.ErrInvalidName{}),
+		errors.As(err, &paramstore.ErrInvalidValue{}),
+		errors.As(err, &paramstore.ErrInvalidType{}),
+		errors.As(err, &paramstore.ErrInvalidTier{}),
+		errors.As(err, &paramstore.ErrInvalidDescription{}),
+		errors.As(err, &paramstore.ErrInvalidPrefix{}),
+		errors.As(err, &paramstore.ErrOverlappingPaths{}),
+		errors.As(err, &paramstore.ErrInvalidLabels{}),
+		errors.As(err, &paramstore.ErrDuplicateName{}),
+		errors.As(err, &paramstore.ErrEnvKeyCollision{}),
+		errors.As(err, &paramstore.ErrNameEscapesPrefix{}),
+		errors.As(err, &paramstore.ErrNameOutsidePath{}),
+		errors.As(err, &paramstore.ErrInvalidArchive{}),
+		errors.As(err, &paramstore.ErrRedactedValue{}),
+		errors.As(err, &paramstore.ErrInvalidConflictPolicy{}),
+		errors.As(err, &paramstore.ErrUnresolvedNameVariables{}),
+		errors.As(err, &paramstore.ErrDeleteNotConfirmed{}),
+		errors.As(err, &paramstore.ErrTooManyParameters{}),
+		errors.As(err, &paramstore.ErrProtectedParameter{}),
+		errors.As(err, &paramstore.ErrInvalidTrashEntry{}),
+		errors.As(err, &paramstore.ErrInvalidChunks{}),
+		errors.As(err, &paramstore.ErrInvalidCodec{}),
+		errors.As(err, &paramstore.ErrInvalidEncodedValue{}),
+		errors.As(err, &paramstore.ErrInvalidKey{}),
+		errors.As(err, &paramstore.ErrUnknownKey{}),
+		errors.As(err, &paramstore.ErrDecryptionFailed{}),
+		errors.As(err, &paramstore.ErrEncrypterNotConfigured{}),
+		errors.As(err, &paramstore.ErrTrashNotConfigured{}):
+		return exitInvalid
+	case
+		errors.As(err, &paramstore.ErrImportConflict{}),
+		errors.As(err, &paramstore.ErrAlreadyExists{}),
+		errors.As(err, &paramstore.ErrVersionConflict{}):
+		return exitConflict
+	case
+		errors.As(err, &paramstore.ErrClientFailedToSetOption{}),
+		errors.As(err, &paramstore.ErrClientFailedToLoadAWSConfig{}):
+		return exitSetup
+	}
+
+	// check errors returned by AWS.
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ParameterNotFound", "ParameterVersionNotFound", "InvalidResourceId":
+			return exitNotFound
+		case "ParameterAlreadyExists":
+			return exitConflict
+		case "AccessDeniedException", "UnrecognizedClientException", "ExpiredTokenException":
+			return exitAccessDenied
+		case "ValidationException", "ParameterPatternMismatchException", "ParameterMaxVersionLimitExceeded":
+			return exitInvalid
+		}
+	}
+	return exitError
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+func Test_exitCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want int
+	}{
+		"no error": {
+			want: exitOK,
+		},
+		"unclassified error": {
+			err:  errors.New("boom"),
+			want: exitError,
+		},
+		"child process exit code": {
+			err:  errExit{42},
+			want: 42,
+		},
+		"usage error": {
+			err:  usageErrorf("unexpected number of arguments"),
+			want: exitUsage,
+		},
+		"param not found": {
+			err:  paramstore.ErrInvalidParameter{Name: "/myapp/missing"},
+			want: exitNotFound,
+		},
+		"invalid name, wrapped": {
+			err:  fmt.Errorf("failed to put: %w", paramstore.ErrInvalidName{}),
+			want: exitInvalid,
+		},
+		"redacted value": {
+			err:  paramstore.ErrRedactedValue{Name: "/myapp/password"},
+			want: exitInvalid,
+		},
+		"version conflict, in a multierror": {
+			err:  multierror.Append(nil, errors.New("boom"), paramstore.ErrVersionConflict{Name: "/myapp/host"}),
+			want: exitConflict,
+		},
+		"already exists": {
+			err:  paramstore.ErrAlreadyExists{Name: "/myapp/host"},
+			want: exitConflict,
+		},
+		"client setup": {
+			err:  paramstore.ErrClientFailedToSetOption{},
+			want: exitSetup,
+		},
+		"aws param not found": {
+			err:  &smithy.GenericAPIError{Code: "ParameterNotFound"},
+			want: exitNotFound,
+		},
+		"aws access denied": {
+			err:  &smithy.GenericAPIError{Code: "AccessDeniedException"},
+			want: exitAccessDenied,
+		},
+		"aws validation": {
+			err:  &smithy.GenericAPIError{Code: "ValidationException"},
+			want: exitInvalid,
+		},
+		"aws unclassified": {
+			err:  &smithy.GenericAPIError{Code: "ThrottlingException"},
+			want: exitError,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() returned an unexpected exit code; want=%v, got=%v", tt.want, got)
+			}
+		})
+	}
+}
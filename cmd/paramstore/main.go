@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+var (
+
+	// the name of this binary.
+	Name = "paramstore"
+
+	// the version of this binary.
+	Version = "HEAD"
+)
+
+// handler holds the config and clients shared by every command.
+type handler struct {
+
+	// config.
+	name    string
+	version string
+	dryRun  bool
+
+	// clients.
+	paramstoresvc *paramstore.Client
+	options       []paramstore.Option // The options paramstoresvc was created with.
+
+	// io.
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a single subcommand of this binary.
+type command struct {
+	usage   string // The arguments this command expects.
+	summary string // A one line summary of what this command does.
+	decrypt bool   // If true, the client always decrypts parameters for this command.
+	run     func(ctx context.Context, h *handler, args []string) error
+}
+
+// commands are the subcommands of this binary, keyed by name.
+// NOTE: this is populated in init() since each command refers back to this map
+// when printing its usage.
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"get":       {usage: "[-version N | -label L] [-o FORMAT] [-reveal] NAME...", summary: "Print the value of one or more parameters.", run: runGet},
+		"put":       {usage: "[-type T] [-tier T] [-codec C] [-overwrite | -if-version N | -if-absent] NAME VALUE", summary: "Upload a parameter; use - as VALUE to read from stdin.", run: runPut},
+		"resolve":   {usage: "[-o FORMAT] [-- COMMAND [ARGS...]]", summary: "Resolve ssm:// references in the environment, then run a command or print them.", decrypt: true, run: runResolve},
+		"rm":        {usage: "NAME... | -r [-confirm PATH | -force] [-max N] [-export FILE] PATH", summary: "Delete one or more parameters, or every parameter under a path.", run: runRm},
+		"ls":        {usage: "[-r] [-l | -o FORMAT] [-reveal] [PATH]", summary: "List the parameters under a path.", run: runLs},
+		"tree":      {usage: "[PATH]", summary: "Print the parameters under a path as a tree.", run: runTree},
+		"history":   {usage: "NAME", summary: "Print every version of a parameter.", run: runHistory},
+		"label":     {usage: "[-version N] NAME LABEL...", summary: "Attach labels to a version of a parameter.", run: runLabel},
+		"tag":       {usage: "[-d] NAME [KEY=VALUE... | KEY...]", summary: "List, add or (with -d) remove the tags on a parameter.", run: runTag},
+		"cp":        {usage: "[-r] [-overwrite] [-dry-run] [-kms-key K] [-dest-region R] [-dest-profile P] SRC DST", summary: "Copy a parameter, or every parameter under a path.", decrypt: true, run: runCp},
+		"diff":      {usage: "[-right-region R] [-right-profile P] [-exit-code] LEFT RIGHT", summary: "Print the differences between the parameters under two paths.", run: runDiff},
+		"exec":      {usage: "-path PATH [-path PATH...] [-case C] [-separator S] [-keep-path] [-override] -- COMMAND [ARGS...]", summary: "Run a command with the parameters under one or more paths as its environment.", decrypt: true, run: runExec},
+		"mv":        {usage: "[-r] [-overwrite] [-dry-run] [-kms-key K] [-dest-region R] [-dest-profile P] SRC DST", summary: "Move a parameter, or every parameter under a path.", decrypt: true, run: runMv},
+		"plan":      {usage: "[-delete] [-format F] FILE PATH", summary: "Print the changes needed to make the parameters under a path match a file.", run: runPlan},
+		"apply":     {usage: "[-delete] [-format F] FILE PATH", summary: "Make the parameters under a path match a file; use - as FILE to read from stdin.", run: runApply},
+		"export":    {usage: "[-out FILE] PATH", summary: "Write every parameter under a path, with its metadata, to an archive.", run: runExport},
+		"import":    {usage: "[-conflict fail|skip|overwrite] [-path PATH] FILE", summary: "Restore every parameter in an archive; use - as FILE to read from stdin.", run: runImport},
+		"trash":     {usage: "[-restore NAME... | -purge DURATION]", summary: "List, restore or purge the parameters deleted with -trash-prefix or -trash-dir.", run: runTrash},
+		"reencrypt": {usage: "PATH", summary: "Re-encrypt the parameters under a path that were encrypted with an older -encryption-key.", run: runReencrypt},
+		"render":    {usage: "[-out FILE] [-mode MODE] TEMPLATE", summary: "Render a template that looks up parameters; use - as TEMPLATE to read from stdin.", decrypt: true, run: runRender},
+	}
+}
+
+func main() {
+
+	// setup handler.
+	h := &handler{
+		name:    Name,
+		version: Version,
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+	}
+	os.Exit(h.main(context.Background(), os.Args[1:]))
+}
+
+// main is like main but after the handler is configured, returning the exit
+// code for this binary.
+func (h *handler) main(ctx context.Context, args []string) int {
+
+	// setup global flags.
+	fs := flag.NewFlagSet(h.name, flag.ContinueOnError)
+	fs.SetOutput(h.stderr)
+	region := fs.String("region", getEnv("AWS_REGION", "ap-southeast-2"), "The AWS region to use.")
+	profile := fs.String("profile", os.Getenv("AWS_PROFILE"), "The AWS profile to use.")
+	prefix := fs.String("prefix", "", "A prefix added to every parameter name.")
+	decrypt := fs.Bool("decrypt", false, "Decrypt SecureString parameters.")
+	largeValues := fs.Bool("large-values", false, "Split values too large for their tier into chunks, and reassemble them.")
+	var encryptionKeys stringsFlag
+	fs.Var(&encryptionKeys, "encryption-key", "An ID=FILE of a base64 encoded AES key, used to encrypt values client-side; can be given more than once, the first is used for new values.")
+	requireEncryption := fs.Bool("require-encryption", false, "Reject values that weren't encrypted with an -encryption-key.")
+	idempotent := fs.Bool("idempotent", false, "Only upload parameters that differ from their current value.")
+	var protect stringsFlag
+	fs.Var(&protect, "protect", "A prefix that rm -r refuses to delete parameters under; can be given more than once.")
+	trashPrefix := fs.String("trash-prefix", "", "Soft-delete parameters, by moving them under this prefix before deleting them.")
+	trashDir := fs.String("trash-dir", "", "Soft-delete parameters, by moving them to this local directory before deleting them.")
+	redaction := fs.String("redact", "mask", "How SecureString values are redacted in output (mask, hash or length).")
+	dryRun := fs.Bool("dry-run", false, "Print the changes that would be made, without making them.")
+	logLevel := fs.String("log-level", "none", "The log level (debug, info, warn, error or none).")
+	fs.Usage = func() { h.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	// determine command.
+	if fs.NArg() == 0 {
+		h.usage(fs)
+		return exitUsage
+	}
+	name := fs.Arg(0)
+	if name == "version" {
+		fmt.Fprintf(h.stdout, "%v %v\n", h.name, h.version)
+		return exitOK
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(h.stderr, "%v: unknown command %q\n", h.name, name)
+		h.usage(fs)
+		return exitUsage
+	}
+
+	// setup client.
+	h.dryRun = *dryRun
+	level, err := parseLogLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintf(h.stderr, "%v: %v\n", h.name, err)
+		return exitUsage
+	}
+	policy, err := paramstore.ParseRedactionPolicy(*redaction)
+	if err != nil {
+		fmt.Fprintf(h.stderr, "%v: %v\n", h.name, err)
+		return exitUsage
+	}
+	h.options = []paramstore.Option{
+		paramstore.WithAWSRegion(*region),
+		paramstore.WithAWSProfile(*profile),
+		paramstore.WithPrefix(*prefix),
+		paramstore.WithDecryption(*decrypt || cmd.decrypt),
+		paramstore.WithDryRun(*dryRun),
+		paramstore.WithIdempotentPut(*idempotent),
+		paramstore.WithLargeValues(*largeValues),
+		paramstore.WithProtectedPrefixes(protect...),
+		paramstore.WithRedactionPolicy(policy),
+		paramstore.WithLogger(slog.New(slog.NewTextHandler(h.stderr, &slog.HandlerOptions{Level: level}))),
+	}
+	switch {
+	case *trashPrefix != "" && *trashDir != "":
+		fmt.Fprintf(h.stderr, "%v: -trash-prefix and -trash-dir cannot be used together\n", h.name)
+		return exitUsage
+	case *trashPrefix != "":
+		h.options = append(h.options, paramstore.WithTrashPrefix(*trashPrefix))
+	case *trashDir != "":
+		h.options = append(h.options, paramstore.WithTrash(paramstore.NewDirTrash(*trashDir)))
+	}
+	if *requireEncryption && len(encryptionKeys) == 0 {
+		fmt.Fprintf(h.stderr, "%v: -require-encryption needs an -encryption-key\n", h.name)
+		return exitUsage
+	}
+	if len(encryptionKeys) > 0 {
+		e, err := loadEncrypter(encryptionKeys)
+		if err != nil {
+			fmt.Fprintf(h.stderr, "%v: %v\n", h.name, err)
+			return exitCode(err)
+		}
+		h.options = append(h.options, paramstore.WithEncrypter(e))
+		h.options = append(h.options, paramstore.WithRequireEncryption(*requireEncryption))
+	}
+	h.paramstoresvc, err = paramstore.New(ctx, h.options...)
+	if err != nil {
+		fmt.Fprintf(h.stderr, "%v: failed to setup client: %v\n", h.name, err)
+		return exitCode(err)
+	}
+
+	// ~start!
+	if err := cmd.run(ctx, h, fs.Args()[1:]); err != nil {
+		if !errors.As(err, &errExit{}) {
+			fmt.Fprintf(h.stderr, "%v %v: %v\n", h.name, name, err)
+		}
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+// usage prints how to use this binary.
+func (h *handler) usage(fs *flag.FlagSet) {
+	fmt.Fprintf(h.stderr, "usage: %v [flags] COMMAND [args]\n\ncommands:\n", h.name)
+	names := make([]string, 0, len(commands))
+	for n := range commands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(h.stderr, "  %-8v %v\n", n, commands[n].summary)
+		fmt.Fprintf(h.stderr, "  %-8v   %v %v\n", "", n, commands[n].usage)
+	}
+	fmt.Fprintf(h.stderr, "\nflags:\n")
+	fs.PrintDefaults()
+}
+
+// parseLogLevel converts the given log level into a slog.Level.
+func parseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	case "none":
+		return slog.LevelError + 1, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", level)
+}
+
+// getEnv retrieves an environment variable value with a default fallback.
+func getEnv(key, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
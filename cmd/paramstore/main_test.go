@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/jmpa-io/paramstore"
+	"github.com/jmpa-io/paramstore/internal/mockssm"
+)
+
+// store is the parameters every handler in these tests starts with.
+var store = paramstore.Parameters{
+	{Name: "/myapp/prod/host", Value: "db.prod", Type: paramstore.ParameterTypeString},
+	{Name: "/myapp/prod/password", Value: "hunter2", Type: paramstore.ParameterTypeSecureString},
+}
+
+// newTestContext returns a context holding a mock store, populated with
+// store, which every handler given the context uses instead of AWS.
+func newTestContext(t *testing.T) context.Context {
+	ctx := mockssm.NewContext(context.Background(), &mockssm.Store{})
+	c, err := paramstore.New(ctx, paramstore.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+	if err := c.Put(ctx, store); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	return ctx
+}
+
+// newTestHandler returns a handler, with its stdout and stderr.
+func newTestHandler() (h *handler, stdout, stderr *bytes.Buffer) {
+	stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	h = &handler{
+		name:    "paramstore",
+		version: "test",
+		stdin:   strings.NewReader(""),
+		stdout:  stdout,
+		stderr:  stderr,
+	}
+	return h, stdout, stderr
+}
+
+func Test_handler_main(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		want    int
+		stdout  string // A string stdout must contain.
+		exclude string // A string stdout mustn't contain.
+	}{
+		"no command": {
+			want: exitUsage,
+		},
+		"unknown command": {
+			args: []string{"nope"},
+			want: exitUsage,
+		},
+		"invalid redaction policy": {
+			args: []string{"-redact", "reveal", "ls", "/myapp/prod"},
+			want: exitUsage,
+		},
+		"get": {
+			args:   []string{"get", "/myapp/prod/host"},
+			want:   exitOK,
+			stdout: "db.prod",
+		},
+		"get missing param": {
+			args: []string{"get", "/myapp/prod/missing"},
+			want: exitNotFound,
+		},
+		"get with a prefix": {
+			args:   []string{"-prefix", "/myapp", "get", "/prod/host"},
+			want:   exitOK,
+			stdout: "db.prod",
+		},
+		"ls": {
+			args:   []string{"ls", "/myapp/prod"},
+			want:   exitOK,
+			stdout: "/myapp/prod/password",
+		},
+		"ls, redacting values": {
+			args:    []string{"-decrypt", "ls", "-o", "dotenv", "/myapp/prod"},
+			want:    exitOK,
+			stdout:  `MYAPP_PROD_PASSWORD="********"`,
+			exclude: "hunter2",
+		},
+		"ls, redacting values with a policy": {
+			args:    []string{"-decrypt", "-redact", "length", "ls", "-o", "dotenv", "/myapp/prod"},
+			want:    exitOK,
+			stdout:  `MYAPP_PROD_PASSWORD="<7 bytes>"`,
+			exclude: "hunter2",
+		},
+		"ls, revealing values": {
+			args:   []string{"-decrypt", "ls", "-o", "dotenv", "-reveal", "/myapp/prod"},
+			want:   exitOK,
+			stdout: `MYAPP_PROD_PASSWORD="hunter2"`,
+		},
+		"put existing param": {
+			args: []string{"put", "/myapp/prod/host", "db.new"},
+			want: exitConflict,
+		},
+		"put invalid name": {
+			args: []string{"put", "my app", "db.new"},
+			want: exitInvalid,
+		},
+		"put with too many arguments": {
+			args: []string{"put", "/myapp/prod/host", "db.new", "extra"},
+			want: exitUsage,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h, stdout, stderr := newTestHandler()
+			if got := h.main(newTestContext(t), tt.args); got != tt.want {
+				t.Errorf("main() returned an unexpected exit code; want=%v, got=%v, stderr=%v", tt.want, got, stderr)
+			}
+			if !strings.Contains(stdout.String(), tt.stdout) {
+				t.Errorf("main() printed an unexpected output; want=%v, got=%v", tt.stdout, stdout)
+			}
+			if tt.exclude != "" && strings.Contains(stdout.String(), tt.exclude) {
+				t.Errorf("main() printed an unredacted value; got=%v", stdout)
+			}
+		})
+	}
+}
+
+func Test_handler_main_put(t *testing.T) {
+	ctx := newTestContext(t)
+
+	// put param, reading its value from stdin.
+	h, _, stderr := newTestHandler()
+	h.stdin = strings.NewReader("db.new\n")
+	if got := h.main(ctx, []string{"put", "-overwrite", "/myapp/prod/host", "-"}); got != exitOK {
+		t.Fatalf("main() returned an unexpected exit code; want=%v, got=%v, stderr=%v", exitOK, got, stderr)
+	}
+
+	// get param, with a new handler sharing the same store.
+	h, stdout, stderr := newTestHandler()
+	if got := h.main(ctx, []string{"get", "/myapp/prod/host"}); got != exitOK {
+		t.Fatalf("main() returned an unexpected exit code; want=%v, got=%v, stderr=%v", exitOK, got, stderr)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "db.new" {
+		t.Errorf("main() didn't put the param; want=db.new, got=%v", got)
+	}
+
+	// catch a conditional put of a stale version.
+	h, _, _ = newTestHandler()
+	if got := h.main(ctx, []string{"put", "-if-version", "1", "/myapp/prod/host", "db.stale"}); got != exitConflict {
+		t.Errorf("main() returned an unexpected exit code; want=%v, got=%v", exitConflict, got)
+	}
+}
+
+func Test_handler_main_mv(t *testing.T) {
+	ctx := newTestContext(t)
+
+	// catch a move onto itself, via a destination client in the same region.
+	h, stdout, stderr := newTestHandler()
+	args := []string{"mv", "-r", "-overwrite", "-dest-region", "ap-southeast-2", "/myapp/prod", "/myapp/prod"}
+	if got := h.main(ctx, args); got != exitInvalid {
+		t.Fatalf("main() returned an unexpected exit code; want=%v, got=%v, stdout=%v, stderr=%v", exitInvalid, got, stdout, stderr)
+	}
+
+	// check every param is still there.
+	h, stdout, stderr = newTestHandler()
+	if got := h.main(ctx, []string{"ls", "/myapp/prod"}); got != exitOK {
+		t.Fatalf("main() returned an unexpected exit code; want=%v, got=%v, stderr=%v", exitOK, got, stderr)
+	}
+	for _, p := range store {
+		if !strings.Contains(stdout.String(), p.Name) {
+			t.Errorf("main() lost a param; want=%v, got=%v", p.Name, stdout)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmpa-io/paramstore"
+)
+
+// runPlan prints the changes needed to make the parameters under a path match
+// a file.
+func runPlan(ctx context.Context, h *handler, args []string) error {
+	_, err := plan(ctx, h, "plan", args)
+	return err
+}
+
+// runApply makes the parameters under a path match a file.
+func runApply(ctx context.Context, h *handler, args []string) error {
+	p, err := plan(ctx, h, "apply", args)
+	if err != nil || p.Empty() {
+		return err
+	}
+	return h.paramstoresvc.Apply(ctx, p)
+}
+
+// plan reads the desired parameters from a file, for the given command, then
+// prints and returns the plan for them.
+func plan(ctx context.Context, h *handler, name string, args []string) (paramstore.Plan, error) {
+	fs := h.flags(name)
+	del := fs.Bool("delete", false, "Delete parameters under PATH that aren't in FILE.")
+	format := fs.String("format", "", "The format of FILE (json, yaml or toml); defaults to the file extension.")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return paramstore.Plan{}, err
+	}
+	file, path := fs.Arg(0), fs.Arg(1)
+
+	// determine format.
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(file), ".")
+		if *format == "yml" {
+			*format = string(paramstore.FormatYAML)
+		}
+	}
+	f, err := paramstore.ParseFormat(*format)
+	if err != nil {
+		return paramstore.Plan{}, usageErrorf("unable to determine the format of %q; use -format", file)
+	}
+
+	// read desired parameters.
+	var r io.Reader = h.stdin
+	if file != "-" {
+		fh, err := os.Open(file)
+		if err != nil {
+			return paramstore.Plan{}, fmt.Errorf("failed to read parameters: %w", err)
+		}
+		defer fh.Close()
+		r = fh
+	}
+	desired, err := paramstore.DecodeParameters(r, f)
+	if err != nil {
+		return paramstore.Plan{}, fmt.Errorf("failed to read parameters from %v: %w", file, err)
+	}
+
+	// make plan.
+	p, err := h.paramstoresvc.Plan(ctx, desired, path, paramstore.PlanOptions{Delete: *del})
+	if err != nil {
+		return paramstore.Plan{}, err
+	}
+	return p, p.Diff(h.stdout, h.paramstoresvc.RedactionPolicy())
+}
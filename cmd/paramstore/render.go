@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// runRender renders a template, using the functions from
+// paramstore.TemplateFuncs to look up parameters.
+func runRender(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("render")
+	output := fs.String("out", "", "The file to write the output to; defaults to stdout.")
+	mode := fs.String("mode", "0644", "The file mode of the output file, in octal.")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	perm, err := strconv.ParseUint(*mode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return usageErrorf("-mode must be an octal file mode, like 0644; got %q", *mode)
+	}
+
+	// read template.
+	var text []byte
+	if fs.Arg(0) == "-" {
+		text, err = io.ReadAll(h.stdin)
+	} else {
+		text, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	// render template.
+	// NOTE: the output is rendered in full before it's written anywhere, so a
+	// failed lookup never leaves a partially written file behind.
+	var buf bytes.Buffer
+	if err := h.paramstoresvc.RenderTemplate(ctx, &buf, filepath.Base(fs.Arg(0)), string(text), nil); err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err := buf.WriteTo(h.stdout)
+		return err
+	}
+	return writeFileAtomic(*output, buf.Bytes(), os.FileMode(perm))
+}
+
+// writeFileAtomic writes the given data to a temporary file next to the given
+// path, then renames it over the path, so readers only ever see the old or the
+// new contents of the file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+
+	// create temporary file.
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	// write temporary file.
+	if err := f.Chmod(perm); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	// replace file.
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %v: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+)
+
+// runTrash lists, restores or purges the parameters in the trash.
+func runTrash(ctx context.Context, h *handler, args []string) error {
+	fs := h.flags("trash")
+	restore := fs.Bool("restore", false, "Restore the given parameters from the trash.")
+	purge := fs.Duration("purge", -1, "Permanently remove the parameters deleted more than this long ago (eg. 720h).")
+	if err := parse(fs, args, 0, -1); err != nil {
+		return err
+	}
+
+	switch {
+	case *restore && *purge >= 0:
+		return usageErrorf("-restore and -purge cannot be used together")
+
+	case *restore:
+		if fs.NArg() == 0 {
+			return usageErrorf("no parameters given to restore")
+		}
+		for _, n := range fs.Args() {
+			if err := h.paramstoresvc.Restore(ctx, n); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *purge >= 0:
+		purged, err := h.paramstoresvc.PurgeTrash(ctx, *purge)
+		for _, n := range purged {
+			fmt.Fprintln(h.stdout, n)
+		}
+		return err
+	}
+
+	// list entries.
+	entries, err := h.paramstoresvc.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(h.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DELETED\tVERSION\tTYPE\tNAME")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n",
+			e.DeletedAt.Format(time.RFC3339),
+			e.Version,
+			e.Type,
+			e.Name,
+		)
+	}
+	return w.Flush()
+}
@@ -92,7 +92,7 @@ func main() {
 	defer span.End()
 
 	// setup client.
-	h.paramstoresvc, err = paramstore.New(ctx)
+	h.paramstoresvc, err = paramstore.New(ctx, paramstore.WithTracerProvider(tp))
 	if err != nil {
 		h.logger.Error("failed to setup client", "client", "paramstore")
 		os.Exit(1)
@@ -0,0 +1,191 @@
+package paramstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec is the encoding applied to a value before it's uploaded, which is
+// written as a header in front of the encoded value, such as
+// "paramstore+gzip:H4sI...", so it's reversed automatically when retrieved.
+type Codec string
+
+const (
+	CodecPlain  Codec = "plain"  // The value is uploaded as-is, without a header.
+	CodecBase64 Codec = "base64" // The value is base64 encoded, for binary values.
+	CodecGzip   Codec = "gzip"   // The value is compressed with gzip, then base64 encoded.
+	CodecZstd   Codec = "zstd"   // The value is compressed with zstd, then base64 encoded.
+)
+
+const (
+	// the prefix of the header written in front of every encoded value.
+	codecHeaderPrefix = "paramstore+"
+
+	// the max size of a decoded value, so a small compressed value can't
+	// exhaust the memory of every client that retrieves it.
+	maxDecodedValueSize = 4 << 20
+)
+
+// errDecodedValueTooLarge is returned when a decoded value would be larger
+// than maxDecodedValueSize.
+var errDecodedValueTooLarge = fmt.Errorf("decoded value is larger than %v bytes", maxDecodedValueSize)
+
+// ParseCodec converts the given string into a Codec.
+func ParseCodec(s string) (Codec, error) {
+	switch c := Codec(strings.ToLower(s)); c {
+	case CodecPlain, CodecBase64, CodecGzip, CodecZstd:
+		return c, nil
+	}
+	return "", ErrInvalidCodec{Codec(s)}
+}
+
+// codecRule is a codec used for every param under a prefix.
+type codecRule struct {
+	prefix string // The (qualified) prefix; "" for every param.
+	codec  Codec
+}
+
+// codecFor returns the codec used for the given (qualified) param: the codec
+// of the param, if set, otherwise the codec of the longest matching prefix
+// configured via WithCodec.
+func (c *Client) codecFor(p Parameter) Codec {
+	if p.Codec != "" {
+		return p.Codec
+	}
+	codec, longest := CodecPlain, -1
+	for _, r := range c.codecs {
+		if len(r.prefix) > longest && (r.prefix == "" || p.Name == r.prefix || strings.HasPrefix(p.Name, r.prefix+"/")) {
+			codec, longest = r.codec, len(r.prefix)
+		}
+	}
+	return codec
+}
+
+// encode encodes the given value, including the header.
+func (codec Codec) encode(value string) (string, error) {
+	var b []byte
+	switch codec {
+	case CodecPlain:
+		return value, nil
+	case CodecBase64:
+		b = []byte(value)
+	case CodecGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write([]byte(value)); err != nil {
+			return "", err
+		}
+		if err := w.Close(); err != nil {
+			return "", err
+		}
+		b = buf.Bytes()
+	case CodecZstd:
+		e, err := zstd.NewWriter(nil)
+		if err != nil {
+			return "", err
+		}
+		b = e.EncodeAll([]byte(value), nil)
+		e.Close()
+	default:
+		return "", ErrInvalidCodec{codec}
+	}
+	return codecHeaderPrefix + string(codec) + ":" + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// decodeValue decodes the given value, if it has a header; values without a
+// header, such as values uploaded by other tools, are returned as-is.
+func decodeValue(value string) (string, error) {
+	rest, ok := strings.CutPrefix(value, codecHeaderPrefix)
+	if !ok {
+		return value, nil
+	}
+	name, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return value, nil
+	}
+	codec := Codec(name)
+	switch codec {
+	case CodecBase64, CodecGzip, CodecZstd:
+	default:
+		return value, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	switch codec {
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return "", err
+		}
+		defer r.Close()
+		if b, err = io.ReadAll(io.LimitReader(r, maxDecodedValueSize+1)); err != nil {
+			return "", err
+		}
+		if len(b) > maxDecodedValueSize {
+			return "", errDecodedValueTooLarge
+		}
+	case CodecZstd:
+		d, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecodedValueSize), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return "", err
+		}
+		defer d.Close()
+		if b, err = d.DecodeAll(b, nil); err != nil {
+			if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+				return "", errDecodedValueTooLarge
+			}
+			return "", err
+		}
+	}
+	return string(b), nil
+}
+
+// encodeAll encodes the value of each of the given (qualified) params, using
+// the codec for each param. Empty values are left as-is, so they're still
+// caught by Validate().
+func (c *Client) encodeAll(qualified Parameters) (errs error) {
+	for i, p := range qualified {
+		if p.Value == "" {
+			continue
+		}
+		codec := c.codecFor(p)
+		if codec != CodecPlain && len(p.Value) > maxDecodedValueSize {
+			errs = multierror.Append(errs, ErrInvalidValue{p.Name, fmt.Sprintf("value is too large to encode (%v bytes)", len(p.Value))})
+			continue
+		}
+		v, err := codec.encode(p.Value)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		qualified[i].Value = v
+	}
+	return errs
+}
+
+// decodeAll decodes the value of each of the given params that has a header.
+func (c *Client) decodeAll(params Parameters) (errs error) {
+	for i, p := range params {
+		v, err := decodeValue(p.Value)
+		if err != nil {
+			c.logger.Error("failed to decode parameter",
+				"error", err,
+				"name", p.Name,
+			)
+			errs = multierror.Append(errs, ErrInvalidEncodedValue{p.Name, err.Error()})
+			continue
+		}
+		params[i].Value = v
+	}
+	return errs
+}
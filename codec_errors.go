@@ -0,0 +1,23 @@
+package paramstore
+
+import "fmt"
+
+// ErrInvalidCodec is returned when a codec isn't supported.
+type ErrInvalidCodec struct {
+	codec Codec
+}
+
+func (e ErrInvalidCodec) Error() string {
+	return fmt.Sprintf("%q is not a codec", e.codec)
+}
+
+// ErrInvalidEncodedValue is returned when the value of a param has a codec
+// header, but can't be decoded.
+type ErrInvalidEncodedValue struct {
+	Name   string
+	reason string
+}
+
+func (e ErrInvalidEncodedValue) Error() string {
+	return fmt.Sprintf("failed to decode %q: %v", e.Name, e.reason)
+}
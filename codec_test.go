@@ -0,0 +1,170 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func Test_Codec(t *testing.T) {
+	values := map[string]string{
+		"text":   `{"feature":"enabled","rollout":` + strings.Repeat(`"x",`, 100) + `"y"}`,
+		"binary": "\x08\x96\x01\x12\x07testing\x00\xff",
+		"empty":  "",
+	}
+	for _, codec := range []Codec{CodecPlain, CodecBase64, CodecGzip, CodecZstd} {
+		for name, value := range values {
+			t.Run(string(codec)+"/"+name, func(t *testing.T) {
+				encoded, err := codec.encode(value)
+				if err != nil {
+					t.Fatalf("encode() returned an error; error=%v", err)
+				}
+				if codec != CodecPlain && !strings.HasPrefix(encoded, codecHeaderPrefix+string(codec)+":") {
+					t.Errorf("encode() didn't write a header; got=%v", encoded)
+				}
+				got, err := decodeValue(encoded)
+				if err != nil {
+					t.Fatalf("decodeValue() returned an error; error=%v", err)
+				}
+				if got != value {
+					t.Errorf("decodeValue() returned an unexpected value; want=%q, got=%q", value, got)
+				}
+			})
+		}
+	}
+}
+
+func Test_decodeValue(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+		err   bool
+	}{
+		"plain value": {
+			value: "db.prod",
+			want:  "db.prod",
+		},
+		"plain value with a colon": {
+			value: "paramstore+docs: see the readme",
+			want:  "paramstore+docs: see the readme",
+		},
+		"base64 value": {
+			value: "paramstore+base64:aGVsbG8=",
+			want:  "hello",
+		},
+		"invalid base64": {
+			value: "paramstore+base64:not base64!",
+			err:   true,
+		},
+		"invalid gzip": {
+			value: "paramstore+gzip:aGVsbG8=",
+			err:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := decodeValue(tt.value)
+			if (err != nil) != tt.err {
+				t.Errorf("decodeValue() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("decodeValue() returned an unexpected value; want=%q, got=%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_decodeValueTooLarge(t *testing.T) {
+	huge := strings.Repeat("0", maxDecodedValueSize+1)
+	for _, codec := range []Codec{CodecGzip, CodecZstd} {
+		t.Run(string(codec), func(t *testing.T) {
+			encoded, err := codec.encode(huge)
+			if err != nil {
+				t.Fatalf("encode() returned an error; error=%v", err)
+			}
+			if _, err := decodeValue(encoded); !errors.Is(err, errDecodedValueTooLarge) {
+				t.Errorf("decodeValue() returned an unexpected error; want=%v, got=%v", errDecodedValueTooLarge, err)
+			}
+
+			// check the error returned when retrieving the value.
+			mock, _ := newMockSSMStore(Parameters{{Name: "/myapp/bomb", Value: encoded, Type: ParameterTypeString, Tier: ParameterTierAdvanced}})
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			if _, err := c.Get(context.Background(), "/myapp/bomb"); !errors.As(err, &ErrInvalidEncodedValue{}) {
+				t.Errorf("Get() returned an unexpected error; want=ErrInvalidEncodedValue, got=%v", err)
+			}
+			if err := c.Put(context.Background(), Parameters{{Name: "/myapp/huge", Value: huge, Codec: codec}}); !errors.As(err, &ErrInvalidValue{}) {
+				t.Errorf("Put() returned an unexpected error; want=ErrInvalidValue, got=%v", err)
+			}
+		})
+	}
+}
+
+func Test_Codecs(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/other", Value: "from another tool", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	for _, o := range []Option{
+		WithCodec("/myapp", CodecGzip),
+		WithCodec("/myapp/plain/", CodecPlain),
+	} {
+		if err := o(c); err != nil {
+			t.Fatalf("WithCodec() returned an error; error=%v", err)
+		}
+	}
+	params := Parameters{
+		{Name: "/myapp/config", Value: `{"feature":"enabled"}`, Type: ParameterTypeString},
+		{Name: "/myapp/plain/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/proto", Value: "\x08\x96\x01", Type: ParameterTypeString, Codec: CodecBase64},
+		{Name: "/other/host", Value: "db.other", Type: ParameterTypeString},
+	}
+	if err := c.Put(ctx, params); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+
+	// check stored values.
+	want := map[string]string{
+		"/myapp/config":     codecHeaderPrefix + "gzip:",
+		"/myapp/plain/host": "db.prod",
+		"/myapp/proto":      codecHeaderPrefix + "base64:CJYB",
+		"/other/host":       "db.other",
+	}
+	for n, prefix := range want {
+		if v, _ := s.Value(n); !strings.HasPrefix(v, prefix) {
+			t.Errorf("Put() stored an unexpected value for %v; want prefix=%v, got=%v", n, prefix, v)
+		}
+	}
+
+	// check retrieved values.
+	got, err := c.GetMultiple(ctx, append(params.ToSliceString(), "/myapp/other")...)
+	if err != nil {
+		t.Fatalf("GetMultiple() returned an error; error=%v", err)
+	}
+	for i, p := range params {
+		if got[i].Value != p.Value {
+			t.Errorf("GetMultiple() returned an unexpected value for %v; want=%q, got=%q", p.Name, p.Value, got[i].Value)
+		}
+	}
+	byPath, err := c.GetByPath(ctx, "/myapp", true)
+	if err != nil {
+		t.Fatalf("GetByPath() returned an error; error=%v", err)
+	}
+	for _, p := range byPath {
+		if strings.HasPrefix(p.Value, codecHeaderPrefix) {
+			t.Errorf("GetByPath() didn't decode %v; got=%v", p.Name, p.Value)
+		}
+	}
+
+	// catch invalid codec.
+	if err := WithCodec("/myapp", Codec("lz4"))(c); !errors.As(err, &ErrInvalidCodec{}) {
+		t.Errorf("WithCodec() returned an unexpected error; want=ErrInvalidCodec, got=%v", err)
+	}
+	err = c.Put(ctx, Parameters{{Name: "/myapp/new", Value: "hello", Codec: Codec("lz4")}})
+	if !errors.As(err, &ErrInvalidCodec{}) {
+		t.Errorf("Put() returned an unexpected error; want=ErrInvalidCodec, got=%v", err)
+	}
+}
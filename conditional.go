@@ -0,0 +1,282 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// PutIfVersion uploads the given param to paramstore, only if the latest
+// version of the param is still the expected version, returning the new
+// version of the param. An expected version of 0 means the param mustn't
+// exist yet. If the param was changed by someone else, ErrVersionConflict is
+// returned.
+// NOTE:
+// AWS SSM Parameter Store has no conditional write, so the version is checked
+// right before the param is uploaded, then again using the version returned by
+// the upload. If another write raced between the two, the value it wrote is
+// put back as a new version, so it isn't lost, and ErrVersionConflict is
+// returned; this write is still briefly visible, and is kept in the history.
+// If the param was changed again before it can be put back, nothing is put
+// back, and ErrVersionConflict is returned.
+func (c *Client) PutIfVersion(ctx context.Context, parameter Parameter, expectedVersion int64) (int64, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "PutIfVersion")
+	defer span.End()
+
+	// qualify name.
+	given := parameter.Name
+	name, err := c.qualify(given)
+	if err != nil {
+		return 0, err
+	}
+	parameter.Name = name
+	parameter.Overwrite = expectedVersion > 0
+
+	// encode, encrypt and chunk value, then validate param, before making any
+	// calls.
+	params := Parameters{parameter}
+	if err := c.transform(newCtx, params); err != nil {
+		return 0, err
+	}
+	chunks, err := c.chunkValues(params)
+	if err != nil {
+		return 0, err
+	}
+	parameter = params[0]
+	if err := params.Validate(); err != nil {
+		return 0, err
+	}
+
+	// check the latest version.
+	version, err := c.latestVersion(newCtx, name)
+	if err != nil {
+		return 0, err
+	}
+	if version != expectedVersion {
+		return version, ErrVersionConflict{given, expectedVersion, version}
+	}
+
+	// log param, without uploading it.
+	if c.isDryRun(newCtx) {
+		c.logger.Info("dry run: would put parameter",
+			"name", name,
+			"version", expectedVersion+1,
+		)
+		return expectedVersion + 1, nil
+	}
+
+	// put chunks first, so a manifest never refers to missing chunks.
+	if ch, ok := chunks[name]; ok {
+		if err := c.putChunks(newCtx, ch); err != nil {
+			c.discardChunks(newCtx, name)
+			return 0, err
+		}
+	}
+
+	// put parameter.
+	in := c.putInput(parameter)
+	resp, err := c.ssmsvc.PutParameter(newCtx, in)
+	if err != nil {
+		if len(chunks) > 0 {
+			c.discardChunks(newCtx, name)
+		}
+
+		// the param was created since it was checked.
+		var exists *types.ParameterAlreadyExists
+		if errors.As(err, &exists) {
+			version, lerr := c.latestVersion(newCtx, name)
+			if lerr != nil {
+				return 0, lerr
+			}
+			return version, ErrVersionConflict{given, expectedVersion, version}
+		}
+		c.logger.Error("failed to put parameter",
+			"error", err,
+			"name", name,
+			"expectedVersion", expectedVersion,
+		)
+		return 0, err
+	}
+
+	// the param was changed since it was checked, so restore the other write.
+	if resp.Version != expectedVersion+1 {
+		c.logger.Warn("found concurrent change to parameter",
+			"name", name,
+			"expectedVersion", expectedVersion,
+			"version", resp.Version,
+		)
+		conflict := ErrVersionConflict{given, expectedVersion, resp.Version - 1}
+		restored, ok, err := c.restoreVersion(newCtx, name, resp.Version-1, resp.Version)
+		switch {
+		case err != nil:
+			return resp.Version, multierror.Append(conflict, err)
+		case !ok:
+			return resp.Version, conflict
+		}
+
+		// delete the chunks of this write, keeping the chunks of the restored value.
+		if c.largeValues {
+			m, _ := parseManifest(restored.Value)
+			if err := c.cleanupChunks(newCtx, name, m.Path); err != nil {
+				return restored.Version, multierror.Append(conflict, err)
+			}
+		}
+		return restored.Version, conflict
+	}
+
+	// delete chunks left over from a previous value, if needed.
+	if c.largeValues {
+		m, _ := parseManifest(parameter.Value)
+		if err := c.cleanupChunks(newCtx, name, m.Path); err != nil {
+			return resp.Version, err
+		}
+	}
+	return resp.Version, nil
+}
+
+// restoreVersion puts the value and metadata of the given version of the
+// given (qualified) param back as its latest version, undoing a write that
+// raced with it, returning the param restored, with its new version. Nothing
+// is restored, and false is returned, if the latest version isn't the given
+// written version, since the param was changed again since it was written.
+func (c *Client) restoreVersion(ctx context.Context, name string, version, written int64) (Parameter, bool, error) {
+
+	// retrieve the raw history, without any prefix, template or codec applied.
+	raw := *c
+	raw.prefix, raw.nameTemplate, raw.withDecryption = "", "", true
+	history, err := raw.History(ctx, name)
+	if err != nil {
+		return Parameter{}, false, err
+	}
+	if len(history) == 0 || history[len(history)-1].Version != written {
+		return Parameter{}, false, nil
+	}
+
+	// restore version.
+	for _, v := range history {
+		if v.Version != version {
+			continue
+		}
+		p := v.Parameter
+		p.Name, p.Overwrite = name, true
+		resp, err := c.ssmsvc.PutParameter(ctx, c.putInput(p))
+		if err != nil {
+			c.logger.Error("failed to restore parameter",
+				"error", err,
+				"name", name,
+				"version", version,
+			)
+			return Parameter{}, false, err
+		}
+		p.Version = resp.Version
+		return p, true, nil
+	}
+	return Parameter{}, false, nil
+}
+
+// PutIfAbsent uploads the given param to paramstore, only if it doesn't exist
+// yet, returning true if the param was created. A param that already exists
+// isn't an error, and is left unchanged.
+func (c *Client) PutIfAbsent(ctx context.Context, parameter Parameter) (bool, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "PutIfAbsent")
+	defer span.End()
+
+	// qualify name.
+	name, err := c.qualify(parameter.Name)
+	if err != nil {
+		return false, err
+	}
+	parameter.Name = name
+	parameter.Overwrite = false
+
+	// encode, encrypt and chunk value, then validate param, before making any
+	// calls.
+	params := Parameters{parameter}
+	if err := c.transform(newCtx, params); err != nil {
+		return false, err
+	}
+	chunks, err := c.chunkValues(params)
+	if err != nil {
+		return false, err
+	}
+	parameter = params[0]
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+
+	// check the param exists, without uploading it, or its chunks.
+	dryRun := c.isDryRun(newCtx)
+	if dryRun || len(chunks) > 0 {
+		version, err := c.latestVersion(newCtx, name)
+		if err != nil {
+			return false, err
+		}
+		if dryRun {
+			c.logger.Info("dry run: would put parameter",
+				"name", name,
+				"exists", version > 0,
+			)
+			return version == 0, nil
+		}
+		if version > 0 {
+			return false, nil
+		}
+	}
+
+	// put chunks first, so a manifest never refers to missing chunks.
+	if ch, ok := chunks[name]; ok {
+		if err := c.putChunks(newCtx, ch); err != nil {
+			c.discardChunks(newCtx, name)
+			return false, err
+		}
+	}
+
+	// put parameter.
+	in := c.putInput(parameter)
+	if _, err := c.ssmsvc.PutParameter(newCtx, in); err != nil {
+		if len(chunks) > 0 {
+			c.discardChunks(newCtx, name)
+		}
+		var exists *types.ParameterAlreadyExists
+		if errors.As(err, &exists) {
+			return false, nil
+		}
+		c.logger.Error("failed to put parameter",
+			"error", err,
+			"name", name,
+		)
+		return false, err
+	}
+	return true, nil
+}
+
+// latestVersion retrieves the latest version of the given (qualified) name, or
+// 0 if it doesn't exist. The value isn't decrypted, since only the version is
+// needed.
+func (c *Client) latestVersion(ctx context.Context, name string) (int64, error) {
+	resp, err := c.ssmsvc.GetParameters(ctx, &ssm.GetParametersInput{
+		Names:          []string{name},
+		WithDecryption: aws.Bool(false),
+	})
+	if err != nil {
+		c.logger.Error("failed to get latest version of parameter",
+			"error", err,
+			"name", name,
+		)
+		return 0, err
+	}
+	for _, p := range resp.Parameters {
+		if aws.ToString(p.Name) == name {
+			return p.Version, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,15 @@
+package paramstore
+
+import "fmt"
+
+// ErrVersionConflict is returned when a param was changed by someone else,
+// since the expected version was retrieved.
+type ErrVersionConflict struct {
+	Name     string
+	Expected int64 // The version expected; 0 if the param wasn't expected to exist.
+	Actual   int64 // The latest version found; 0 if the param doesn't exist.
+}
+
+func (e ErrVersionConflict) Error() string {
+	return fmt.Sprintf("%q was changed concurrently: expected version %v, found version %v", e.Name, e.Expected, e.Actual)
+}
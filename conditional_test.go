@@ -0,0 +1,178 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+func Test_PutIfVersion(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		parameter  Parameter
+		expected   int64
+		concurrent string // A value written by someone else, right before the param is uploaded.
+		later      string // A value written by someone else, right after the param is uploaded.
+		dryRun     bool
+		want       int64
+		conflict   *ErrVersionConflict
+		value      string
+	}{
+		"matching version": {
+			parameter: Parameter{Name: "/app/host", Value: "db.staging"},
+			expected:  1,
+			want:      2,
+			value:     "db.staging",
+		},
+		"stale version": {
+			parameter: Parameter{Name: "/app/host", Value: "db.staging"},
+			expected:  2,
+			want:      1,
+			conflict:  &ErrVersionConflict{"/app/host", 2, 1},
+			value:     "db.prod",
+		},
+		"absent param": {
+			parameter: Parameter{Name: "/app/new", Value: "hello", Type: ParameterTypeString},
+			want:      1,
+		},
+		"param expected to be absent": {
+			parameter: Parameter{Name: "/app/host", Value: "db.staging"},
+			want:      1,
+			conflict:  &ErrVersionConflict{"/app/host", 0, 1},
+			value:     "db.prod",
+		},
+		"concurrent change": {
+			parameter:  Parameter{Name: "/app/host", Value: "db.staging"},
+			expected:   1,
+			concurrent: "db.other",
+			want:       4,
+			conflict:   &ErrVersionConflict{"/app/host", 1, 2},
+			value:      "db.other",
+		},
+		"concurrent change, then another change": {
+			parameter:  Parameter{Name: "/app/host", Value: "db.staging"},
+			expected:   1,
+			concurrent: "db.other",
+			later:      "db.later",
+			want:       3,
+			conflict:   &ErrVersionConflict{"/app/host", 1, 2},
+			value:      "db.later",
+		},
+		"dry run": {
+			parameter: Parameter{Name: "/app/host", Value: "db.staging"},
+			expected:  1,
+			dryRun:    true,
+			want:      2,
+			value:     "db.prod",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(store)
+			if tt.concurrent != "" {
+				putParameter := mock.PutParameterFunc
+				write := func(ctx context.Context, name *string, value string) error {
+					if value == "" {
+						return nil
+					}
+					_, err := putParameter(ctx, &ssm.PutParameterInput{Name: name, Value: aws.String(value), Type: types.ParameterTypeString, Overwrite: aws.Bool(true)})
+					return err
+				}
+				var raced bool
+				mock.PutParameterFunc = func(ctx context.Context, input *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+					if raced {
+						return putParameter(ctx, input, optFns...)
+					}
+					raced = true
+					if err := write(ctx, input.Name, tt.concurrent); err != nil {
+						return nil, err
+					}
+					resp, err := putParameter(ctx, input, optFns...)
+					if err != nil {
+						return nil, err
+					}
+					return resp, write(ctx, input.Name, tt.later)
+				}
+			}
+			var decrypted bool
+			getParameters := mock.GetParametersFunc
+			mock.GetParametersFunc = func(ctx context.Context, input *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+				decrypted = decrypted || aws.ToBool(input.WithDecryption)
+				return getParameters(ctx, input, optFns...)
+			}
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, dryRun: tt.dryRun}
+			got, err := c.PutIfVersion(context.Background(), tt.parameter, tt.expected)
+			if decrypted {
+				t.Errorf("PutIfVersion() decrypted a value to check its version")
+			}
+			switch {
+			case tt.conflict == nil && err != nil:
+				t.Errorf("PutIfVersion() returned an error; error=%v", err)
+			case tt.conflict != nil:
+				var conflict ErrVersionConflict
+				if !errors.As(err, &conflict) || conflict != *tt.conflict {
+					t.Errorf("PutIfVersion() returned an unexpected error; want=%v, got=%v", *tt.conflict, err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("PutIfVersion() returned an unexpected version; want=%v, got=%v", tt.want, got)
+			}
+			if tt.value != "" {
+				if v, _ := s.Value(tt.parameter.Name); v != tt.value {
+					t.Errorf("PutIfVersion() left an unexpected value; want=%v, got=%v", tt.value, v)
+				}
+			}
+		})
+	}
+}
+
+func Test_PutIfAbsent(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		parameter Parameter
+		dryRun    bool
+		want      bool
+		value     string
+	}{
+		"absent param": {
+			parameter: Parameter{Name: "/app/new", Value: "hello", Type: ParameterTypeString},
+			want:      true,
+			value:     "hello",
+		},
+		"existing param": {
+			parameter: Parameter{Name: "/app/host", Value: "db.staging", Type: ParameterTypeString, Overwrite: true},
+			value:     "db.prod",
+		},
+		"dry run": {
+			parameter: Parameter{Name: "/app/new", Value: "hello", Type: ParameterTypeString},
+			dryRun:    true,
+			want:      true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, dryRun: tt.dryRun}
+			got, err := c.PutIfAbsent(context.Background(), tt.parameter)
+			if err != nil {
+				t.Errorf("PutIfAbsent() returned an error; error=%v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PutIfAbsent() returned an unexpected result; want=%v, got=%v", tt.want, got)
+			}
+			if v, _ := s.Value(tt.parameter.Name); v != tt.value {
+				t.Errorf("PutIfAbsent() left an unexpected value; want=%v, got=%v", tt.value, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// CopyOptions configures how params are copied or moved.
+type CopyOptions struct {
+	Destination *Client // The client to copy params to, for another region or account; defaults to this client.
+	Recursive   bool    // If true, every param under the source path is copied; otherwise the source is a single param.
+	Overwrite   bool    // If true, params that already exist at the destination are overwritten.
+	KeyId       string  // If set, SecureString params are re-encrypted with this KMS key at the destination.
+	DryRun      bool    // If true, nothing is written; the results show what would be copied. Implied by the client's dry-run mode.
+}
+
+// CopyResult is the outcome of copying a single param.
+type CopyResult struct {
+	Source      string // The name of the param copied.
+	Destination string // The name of the param at the destination.
+	Exists      bool   // If true, the param already existed at the destination.
+	Err         error  // The error copying the param, if any.
+}
+
+// CopyResults is a slice of CopyResult.
+type CopyResults []CopyResult
+
+// Failed returns the results for the params that failed to copy.
+func (results CopyResults) Failed() (out CopyResults) {
+	for _, r := range results {
+		if r.Err != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Copy copies a single param, or every param under a path, from srcPath to
+// dstPath, preserving the type, tier, description and tags of each param.
+// SecureString params keep their KMS key when copied within this client;
+// otherwise they're encrypted with opts.KeyId, or the destination's default
+// key, since a KMS key is specific to a region and account.
+func (c *Client) Copy(ctx context.Context, srcPath, dstPath string, opts CopyOptions) (results CopyResults, errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Copy")
+	defer span.End()
+
+	// determine destination.
+	dst := opts.Destination
+	if dst == nil {
+		dst = c
+	}
+	opts.DryRun = opts.DryRun || c.isDryRun(newCtx) || dst.isDryRun(newCtx)
+
+	// retrieve params, with metadata.
+	decrypting := *c
+	decrypting.withDecryption = true
+	var params Parameters
+	var metadata map[string]types.ParameterMetadata
+	if opts.Recursive {
+		found, err := decrypting.GetByPath(newCtx, srcPath, true)
+		if err != nil {
+			return nil, err
+		}
+		if metadata, err = c.describeByPath(newCtx, srcPath); err != nil {
+			return nil, err
+		}
+		params = found
+	} else {
+		p, err := decrypting.Get(newCtx, srcPath)
+		if err != nil {
+			return nil, err
+		}
+		m, ok, err := c.describeName(newCtx, srcPath)
+		if err != nil {
+			return nil, err
+		}
+		metadata = make(map[string]types.ParameterMetadata)
+		if ok {
+			metadata[p.Name] = m
+		}
+		params = Parameters{*p}
+	}
+
+	// convert params for the destination.
+	copies := make(Parameters, len(params))
+	for i, p := range params {
+		cp := Parameter{
+			Name:  dstPath,
+			Value: p.Value,
+			Type:  p.Type,
+		}
+		if opts.Recursive {
+			cp.Name = strings.TrimRight(dstPath, "/") + strings.TrimPrefix(p.Name, strings.TrimRight(srcPath, "/"))
+		}
+		if m, ok := metadata[p.Name]; ok {
+			cp.Tier = ParameterTier(m.Tier)
+			cp.Description = aws.ToString(m.Description)
+			if dst == c {
+				cp.KeyId = aws.ToString(m.KeyId)
+			}
+		}
+		if opts.KeyId != "" && cp.Type == ParameterTypeSecureString {
+			cp.KeyId = opts.KeyId
+		}
+		copies[i] = cp
+	}
+
+	// determine which params already exist at the destination.
+	exists := make(map[string]bool)
+	if len(copies) > 0 {
+		found, err := dst.GetMultiple(newCtx, copies.ToSliceString()...)
+		if err != nil && !onlyInvalidParameters(err) {
+			return nil, err
+		}
+		for _, p := range found {
+			exists[p.Name] = true
+		}
+	}
+
+	// copy params, one at a time.
+	for i, p := range params {
+		cp := copies[i]
+		r := CopyResult{Source: p.Name, Destination: cp.Name, Exists: exists[cp.Name]}
+		switch {
+		case r.Exists && !opts.Overwrite:
+			r.Err = ErrAlreadyExists{cp.Name}
+
+		case opts.DryRun:
+			c.logger.Info("dry run: would copy parameter",
+				"source", p.Name,
+				"destination", cp.Name,
+				"overwrite", r.Exists,
+			)
+
+		default:
+			cp.Overwrite = r.Exists
+			r.Err = c.copyParameter(newCtx, dst, p.Name, cp)
+		}
+		if r.Err != nil {
+			errs = multierror.Append(errs, r.Err)
+		}
+		results = append(results, r)
+	}
+	return results, errs
+}
+
+// copyParameter puts the given param at the destination, then copies the tags
+// of the source param onto it.
+func (c *Client) copyParameter(ctx context.Context, dst *Client, src string, p Parameter) error {
+	tags, err := c.Tags(ctx, src)
+	if err != nil {
+		return err
+	}
+	if err := dst.Put(ctx, Parameters{p}); err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		return dst.Tag(ctx, p.Name, tags)
+	}
+	return nil
+}
+
+// Move copies params in the same way as Copy(), then deletes each source param
+// that was copied successfully.
+func (c *Client) Move(ctx context.Context, srcPath, dstPath string, opts CopyOptions) (results CopyResults, errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Move")
+	defer span.End()
+
+	// check paths, since moving a param onto itself would delete it.
+	dst := opts.Destination
+	if dst == nil {
+		dst = c
+	}
+	same, err := c.sameStore(newCtx, dst)
+	if err != nil {
+		return nil, err
+	}
+	if same {
+		if err := c.checkOverlap(dst, srcPath, dstPath, opts.Recursive); err != nil {
+			return nil, err
+		}
+	}
+
+	// copy params.
+	opts.DryRun = opts.DryRun || c.isDryRun(newCtx) || (opts.Destination != nil && opts.Destination.isDryRun(newCtx))
+	results, errs = c.Copy(newCtx, srcPath, dstPath, opts)
+	if opts.DryRun {
+		return results, errs
+	}
+
+	// delete copied params.
+	var names []string
+	for _, r := range results {
+		if r.Err == nil {
+			names = append(names, r.Source)
+		}
+	}
+	if len(names) > 0 {
+		if err := c.Delete(newCtx, names...); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return results, errs
+}
+
+// sameStore returns true if the given client uses the same paramstore as this
+// client, ie. the same region and AWS account, even if the clients themselves
+// (eg. their profiles) differ.
+func (c *Client) sameStore(ctx context.Context, other *Client) (bool, error) {
+	if other == c || other.ssmsvc == c.ssmsvc {
+		return true, nil
+	}
+	if other.awsRegion != c.awsRegion {
+		return false, nil
+	}
+	account, err := c.account(ctx)
+	if err != nil {
+		return false, err
+	}
+	otherAccount, err := other.account(ctx)
+	if err != nil {
+		return false, err
+	}
+	return account == otherAccount, nil
+}
+
+// account returns the id of the AWS account used by this client.
+func (c *Client) account(ctx context.Context) (string, error) {
+	if c.stssvc == nil {
+		return "", ErrUnknownAccount{errors.New("no sts client configured")}
+	}
+	resp, err := c.stssvc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		c.logger.Error("failed to get caller identity", "error", err)
+		return "", ErrUnknownAccount{err}
+	}
+	return aws.ToString(resp.Account), nil
+}
+
+// checkOverlap returns ErrOverlappingPaths if the given paths are the same
+// (qualified) param, or if recursive, if either path is under the other. The
+// destination path is qualified by the given destination client, which must
+// use the same paramstore as this client.
+func (c *Client) checkOverlap(dstClient *Client, srcPath, dstPath string, recursive bool) error {
+	src, err := c.qualify(srcPath)
+	if err != nil {
+		return err
+	}
+	dst, err := dstClient.qualify(dstPath)
+	if err != nil {
+		return err
+	}
+	src, dst = path.Clean("/"+src), path.Clean("/"+dst)
+	under := func(name, parent string) bool {
+		return parent == "/" || strings.HasPrefix(name, parent+"/")
+	}
+	if src == dst || (recursive && (under(src, dst) || under(dst, src))) {
+		return ErrOverlappingPaths{srcPath, dstPath}
+	}
+	return nil
+}
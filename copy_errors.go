@@ -0,0 +1,38 @@
+package paramstore
+
+import "fmt"
+
+// ErrAlreadyExists is returned when a param would be overwritten, without
+// being asked to overwrite it.
+type ErrAlreadyExists struct {
+	Name string
+}
+
+func (e ErrAlreadyExists) Error() string {
+	return fmt.Sprintf("%q already exists", e.Name)
+}
+
+// ErrOverlappingPaths is returned when params would be moved onto themselves,
+// or into a path that overlaps the source path.
+type ErrOverlappingPaths struct {
+	Source      string
+	Destination string
+}
+
+func (e ErrOverlappingPaths) Error() string {
+	return fmt.Sprintf("cannot move %q to %q, since the paths overlap", e.Source, e.Destination)
+}
+
+// ErrUnknownAccount is returned when the AWS account used by a client can't be
+// determined, so it can't be known if params would be moved onto themselves.
+type ErrUnknownAccount struct {
+	Err error
+}
+
+func (e ErrUnknownAccount) Error() string {
+	return fmt.Sprintf("failed to determine the aws account used: %v", e.Err)
+}
+
+func (e ErrUnknownAccount) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,270 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_Copy(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/staging/host", Value: "db.staging", Type: ParameterTypeString, Tier: ParameterTierAdvanced, Description: "The database host."},
+		{Name: "/app/staging/db/password", Value: "hunter2", Type: ParameterTypeSecureString, KeyId: "alias/staging"},
+		{Name: "/app/prod-canary/host", Value: "db.prod", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		src, dst string
+		opts     CopyOptions
+		want     CopyResults
+		values   map[string]string
+		keyIds   map[string]string
+	}{
+		"copy single param": {
+			src: "/app/staging/host",
+			dst: "/app/other/host",
+			want: CopyResults{
+				{Source: "/app/staging/host", Destination: "/app/other/host"},
+			},
+			values: map[string]string{"/app/other/host": "db.staging"},
+		},
+		"copy path, without overwriting": {
+			src:  "/app/staging",
+			dst:  "/app/prod-canary/",
+			opts: CopyOptions{Recursive: true},
+			want: CopyResults{
+				{Source: "/app/staging/db/password", Destination: "/app/prod-canary/db/password"},
+				{Source: "/app/staging/host", Destination: "/app/prod-canary/host", Exists: true, Err: ErrAlreadyExists{"/app/prod-canary/host"}},
+			},
+			values: map[string]string{
+				"/app/prod-canary/db/password": "hunter2",
+				"/app/prod-canary/host":        "db.prod",
+			},
+			keyIds: map[string]string{"/app/prod-canary/db/password": "alias/staging"},
+		},
+		"copy path, overwriting and re-encrypting": {
+			src:  "/app/staging",
+			dst:  "/app/prod-canary",
+			opts: CopyOptions{Recursive: true, Overwrite: true, KeyId: "alias/prod"},
+			want: CopyResults{
+				{Source: "/app/staging/db/password", Destination: "/app/prod-canary/db/password"},
+				{Source: "/app/staging/host", Destination: "/app/prod-canary/host", Exists: true},
+			},
+			values: map[string]string{"/app/prod-canary/host": "db.staging"},
+			keyIds: map[string]string{"/app/prod-canary/db/password": "alias/prod"},
+		},
+		"dry run": {
+			src:  "/app/staging",
+			dst:  "/app/prod-canary",
+			opts: CopyOptions{Recursive: true, Overwrite: true, DryRun: true},
+			want: CopyResults{
+				{Source: "/app/staging/db/password", Destination: "/app/prod-canary/db/password"},
+				{Source: "/app/staging/host", Destination: "/app/prod-canary/host", Exists: true},
+			},
+			values: map[string]string{"/app/prod-canary/host": "db.prod"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			got, err := c.Copy(context.Background(), tt.src, tt.dst, tt.opts)
+			if (err != nil) != (len(got.Failed()) > 0) {
+				t.Errorf("Copy() returned an unexpected error; error=%v", err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Copy() returned unexpected results;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+			for n, want := range tt.values {
+				p, err := c.Get(context.Background(), n)
+				if err != nil || p.Value != want {
+					t.Errorf("Copy() left an unexpected value for %v; want=%v, got=%+v, err=%v", n, want, p, err)
+				}
+			}
+			for n, want := range tt.keyIds {
+				history, err := c.History(context.Background(), n)
+				if err != nil || history[len(history)-1].KeyId != want {
+					t.Errorf("Copy() left an unexpected KMS key for %v; want=%v, got=%+v, err=%v", n, want, history, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_CopyAcrossClients(t *testing.T) {
+	ctx := context.Background()
+	srcMock, _ := newMockSSMStore(Parameters{
+		{Name: "/app/password", Value: "hunter2", Type: ParameterTypeSecureString, Tier: ParameterTierAdvanced, Description: "The password.", KeyId: "alias/src"},
+	})
+	dstMock, dstStore := newMockSSMStore(nil)
+	src := newTestRegionClient("ap-southeast-2", srcMock)
+	dst := newTestRegionClient("us-east-1", dstMock)
+	if err := src.Tag(ctx, "/app/password", Tags{"team": "platform"}); err != nil {
+		t.Fatalf("Tag() returned an error; error=%v", err)
+	}
+
+	// move param.
+	if _, err := src.Move(ctx, "/app/password", "/app/password", CopyOptions{Destination: dst}); err != nil {
+		t.Fatalf("Move() returned an error; error=%v", err)
+	}
+	if _, err := src.Get(ctx, "/app/password"); !errors.As(err, &ErrInvalidParameter{}) {
+		t.Errorf("Move() didn't delete the source param; err=%v", err)
+	}
+
+	// check type, tier, description and tags are preserved, but not the key.
+	history, err := dst.History(ctx, "/app/password")
+	if err != nil {
+		t.Fatalf("History() returned an error; error=%v", err)
+	}
+	got := history[0].Parameter
+	want := Parameter{Name: "/app/password", Value: "hunter2", Type: ParameterTypeSecureString, Tier: ParameterTierAdvanced, Description: "The password."}
+	got.Version, got.LastModifiedDate = 0, want.LastModifiedDate
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Move() left an unexpected param;\nwant=%+v\ngot=%+v\n", want, got)
+	}
+	if tags, err := dst.Tags(ctx, "/app/password"); err != nil || !reflect.DeepEqual(tags, Tags{"team": "platform"}) {
+		t.Errorf("Move() didn't preserve tags; got=%v, err=%v", tags, err)
+	}
+	if want := []string{"/app/password"}; !reflect.DeepEqual(want, dstStore.Names()) {
+		t.Errorf("Move() left unexpected params; want=%v, got=%v", want, dstStore.Names())
+	}
+}
+
+func Test_CopyWithPrefix(t *testing.T) {
+	ctx := context.Background()
+	mock, _ := newMockSSMStore(Parameters{
+		{Name: "/app/staging/host", Value: "db.staging", Type: ParameterTypeString, Tier: ParameterTierAdvanced, Description: "The database host."},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, prefix: "/app"}
+
+	// copy param, using a name without a leading slash.
+	if _, err := c.Copy(ctx, "staging/host", "/other/host", CopyOptions{}); err != nil {
+		t.Fatalf("Copy() returned an error; error=%v", err)
+	}
+
+	// check tier and description are preserved.
+	history, err := c.History(ctx, "/other/host")
+	if err != nil {
+		t.Fatalf("History() returned an error; error=%v", err)
+	}
+	if got := history[0]; got.Tier != ParameterTierAdvanced || got.Description != "The database host." {
+		t.Errorf("Copy() didn't preserve metadata; got=%+v", got.Parameter)
+	}
+}
+
+func Test_MoveOverlappingPaths(t *testing.T) {
+	tests := map[string]struct {
+		src, dst string
+		opts     CopyOptions
+		prefix   string
+	}{
+		"same param": {
+			src:  "/app/host",
+			dst:  "/app/host/",
+			opts: CopyOptions{Overwrite: true},
+		},
+		"same path": {
+			src:  "/app",
+			dst:  "/app",
+			opts: CopyOptions{Recursive: true, Overwrite: true},
+		},
+		"destination under source": {
+			src:  "/app",
+			dst:  "/app/nested",
+			opts: CopyOptions{Recursive: true},
+		},
+		"source under destination": {
+			src:  "/app/db",
+			dst:  "/app",
+			opts: CopyOptions{Recursive: true, Overwrite: true},
+		},
+		"same param, with a prefix": {
+			src:    "host",
+			dst:    "/host",
+			opts:   CopyOptions{Overwrite: true},
+			prefix: "/app",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(Parameters{
+				{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+				{Name: "/app/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+			})
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, prefix: tt.prefix}
+			tt.opts.Destination = c
+			_, err := c.Move(context.Background(), tt.src, tt.dst, tt.opts)
+			if !errors.As(err, &ErrOverlappingPaths{}) {
+				t.Errorf("Move() returned an unexpected error; want=ErrOverlappingPaths, got=%v", err)
+			}
+			if want := []string{"/app/db/password", "/app/host"}; !reflect.DeepEqual(want, s.Names()) {
+				t.Errorf("Move() left unexpected params; want=%v, got=%v", want, s.Names())
+			}
+		})
+	}
+}
+
+func Test_MoveAcrossClientsInSameStore(t *testing.T) {
+	tests := map[string]struct {
+		region  string
+		account *mockSTSClient
+		shared  bool // If true, the destination shares the source's store.
+		want    any  // A pointer to the type of error Move() must return, if any.
+	}{
+		"same region and account": {
+			region:  "ap-southeast-2",
+			account: newMockAccount("111111111111"),
+			shared:  true,
+			want:    &ErrOverlappingPaths{},
+		},
+		"same region, unknown account": {
+			region: "ap-southeast-2",
+			shared: true,
+			want:   &ErrUnknownAccount{},
+		},
+		"same region, another account": {
+			region:  "ap-southeast-2",
+			account: newMockAccount("222222222222"),
+		},
+		"another region": {
+			region: "us-east-1",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			mock, s := newMockSSMStore(Parameters{
+				{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+				{Name: "/app/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+			})
+			src := newTestRegionClient("ap-southeast-2", mock)
+			src.stssvc = newMockAccount("111111111111")
+
+			// setup destination, as a separate client (eg. with another profile).
+			dstMock, dstStore := newMockSSMStore(nil)
+			if tt.shared {
+				m := *mock
+				dstMock, dstStore = &m, s
+			}
+			dst := newTestRegionClient(tt.region, dstMock)
+			if tt.account != nil {
+				dst.stssvc = tt.account
+			}
+
+			// move params.
+			_, err := src.Move(ctx, "/app", "/app", CopyOptions{Destination: dst, Recursive: true, Overwrite: true})
+			switch {
+			case tt.want == nil && err != nil:
+				t.Fatalf("Move() returned an error; error=%v", err)
+			case tt.want != nil && !errors.As(err, tt.want):
+				t.Fatalf("Move() returned an unexpected error; want=%T, got=%v", tt.want, err)
+			}
+
+			// check no params were lost.
+			if want := []string{"/app/db/password", "/app/host"}; !reflect.DeepEqual(want, dstStore.Names()) {
+				t.Errorf("Move() left unexpected params; want=%v, got=%v", want, dstStore.Names())
+			}
+		})
+	}
+}
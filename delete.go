@@ -2,22 +2,75 @@ package paramstore
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	multierror "github.com/hashicorp/go-multierror"
-	"go.opentelemetry.io/otel"
 )
 
-// Delete deletes one or more params from paramstore.
-func (c *Client) Delete(ctx context.Context, names ...string) (errs error) {
+// Delete deletes one or more params from paramstore. In soft-delete mode, see
+// WithTrash, each param is moved to the trash first, and is only deleted if
+// that succeeds.
+func (c *Client) Delete(ctx context.Context, names ...string) (err error) {
 
 	// setup tracing.
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "Delete")
-	defer span.End()
+	newCtx, span := c.startSpan(ctx, "Delete", attrCount.Int(len(names)))
+	defer func() { endSpan(span, err) }()
 
-	// retrieve params in batches.
-	var invalid []string
+	// delete params.
+	out, err := c.delete(newCtx, names)
+	span.SetAttributes(attrInvalidCount.Int(invalidCount(out)))
+	return err
+}
+
+// DeleteWithOutcomes deletes one or more params from paramstore, in the same
+// way as Delete(), returning the outcome for each param. In dry-run mode, the
+// current params are retrieved to classify each change, but nothing is
+// deleted.
+func (c *Client) DeleteWithOutcomes(ctx context.Context, names ...string) (out Outcomes, err error) {
+
+	// setup tracing.
+	newCtx, span := c.startSpan(ctx, "DeleteWithOutcomes", attrCount.Int(len(names)))
+	defer func() { endSpan(span, err) }()
+
+	// delete params.
+	out, err = c.delete(newCtx, names)
+	span.SetAttributes(attrInvalidCount.Int(invalidCount(out)))
+	return out, err
+}
+
+// delete deletes one or more params from paramstore, returning the outcome for
+// each param.
+func (c *Client) delete(ctx context.Context, names []string) (out Outcomes, errs error) {
+
+	// qualify names.
+	names, given, errs := c.qualifyAll(names)
+	if errs != nil {
+		return nil, errs
+	}
+
+	// classify params, without deleting them.
+	if c.isDryRun(ctx) {
+		current, err := c.current(ctx, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range names {
+			o := Outcome{Name: c.unqualifyFrom(given, n), Action: ChangeActionDelete}
+			if _, ok := current[n]; !ok {
+				o.Action = ChangeActionMissing
+				o.Err = ErrInvalidParameter{o.Name}
+				errs = multierror.Append(errs, o.Err)
+			}
+			c.logger.Info("dry run: would delete parameter",
+				"name", n,
+				"action", string(o.Action),
+			)
+			out = append(out, o)
+		}
+		return out, errs
+	}
+
+	// delete params in batches.
 	for i := 0; i < len(names); i += c.batchSize {
 
 		// determine rolling batch size.
@@ -26,30 +79,66 @@ func (c *Client) Delete(ctx context.Context, names ...string) (errs error) {
 			size = len(names)
 		}
 
+		// move params to the trash, if needed.
+		batch := names[i:size]
+		if c.trash != nil {
+			var failed Outcomes
+			batch, failed = c.trashAll(ctx, batch, given)
+			for _, o := range failed {
+				errs = multierror.Append(errs, o.Err)
+			}
+			out = append(out, failed...)
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
 		// delete params.
 		in := &ssm.DeleteParametersInput{
-			Names: names[i:size],
+			Names: batch,
 		}
-		resp, err := c.ssmsvc.DeleteParameters(newCtx, in)
+		batchCtx, batchSpan := c.startSpan(ctx, "DeleteParameters",
+			attrCount.Int(len(in.Names)),
+			attrBatchIndex.Int(i/c.batchSize),
+		)
+		resp, err := c.ssmsvc.DeleteParameters(batchCtx, in)
 		if err != nil {
 			c.logger.Error("failed to delete parameters",
 				"error", err,
 				"names", in.Names,
 			)
+			batchSpan.SetAttributes(attrRetryCount.Int(errRetryCount(err)))
+			endSpan(batchSpan, err)
 			errs = multierror.Append(errs, err)
+			for _, n := range in.Names {
+				out = append(out, Outcome{Name: c.unqualifyFrom(given, n), Action: ChangeActionDelete, Err: err})
+			}
 			continue
 		}
-		invalid = append(invalid, resp.InvalidParameters...)
-	}
+		batchSpan.SetAttributes(
+			attrInvalidCount.Int(len(resp.InvalidParameters)),
+			attrRetryCount.Int(retryCount(resp.ResultMetadata)),
+		)
+		batchSpan.End()
+		for _, n := range resp.DeletedParameters {
+			out = append(out, Outcome{Name: c.unqualifyFrom(given, n), Action: ChangeActionDelete})
 
-	// return errs.
-	if len(invalid) > 0 {
-		for _, i := range invalid {
+			// delete chunks, unless the param can be restored from the trash.
+			if c.largeValues && c.trash == nil {
+				if err := c.cleanupChunks(ctx, n, ""); err != nil {
+					errs = multierror.Append(errs, err)
+				}
+			}
+		}
+		for _, n := range resp.InvalidParameters {
 			c.logger.Warn("found invalid parameter",
-				"param", i,
+				"param", n,
 			)
-			errs = multierror.Append(errs, fmt.Errorf("%q is an invalid parameter", i))
+			o := Outcome{Name: c.unqualifyFrom(given, n), Action: ChangeActionMissing}
+			o.Err = ErrInvalidParameter{o.Name}
+			errs = multierror.Append(errs, o.Err)
+			out = append(out, o)
 		}
 	}
-	return errs
+	return out, errs
 }
@@ -0,0 +1,89 @@
+package paramstore
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DeletePathOptions configures the guards used by DeletePath().
+type DeletePathOptions struct {
+	Confirm  string    // Must be the path being deleted, unless Force is set.
+	Force    bool      // If true, no confirmation is needed.
+	MaxCount int       // If set, nothing is deleted if more params than this are found under the path.
+	Export   io.Writer // If set, the params are exported here, via Export(), before they're deleted.
+}
+
+// DeletePath deletes every param under the given path, in batches, returning
+// the outcome for each param. Nothing is deleted unless the path is given
+// again as opts.Confirm, or opts.Force is set, or if any param is found under
+// a prefix protected via WithProtectedPrefixes, or if more than opts.MaxCount
+// params are found.
+func (c *Client) DeletePath(ctx context.Context, path string, opts DeletePathOptions) (Outcomes, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "DeletePath")
+	defer span.End()
+
+	// check confirmation.
+	if !opts.Force && opts.Confirm != path {
+		return nil, ErrDeleteNotConfirmed{path}
+	}
+
+	// retrieve params.
+	metadata, err := c.describeByPath(newCtx, path)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(metadata))
+	for n := range metadata {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	// check guards, before deleting anything.
+	if opts.MaxCount > 0 && len(names) > opts.MaxCount {
+		return nil, ErrTooManyParameters{path, len(names), opts.MaxCount}
+	}
+	for _, n := range names {
+		qualified, err := c.qualify(n)
+		if err != nil {
+			return nil, err
+		}
+		if prefix, ok := c.protectedBy(qualified); ok {
+			return nil, ErrProtectedParameter{n, prefix}
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// export params.
+	if opts.Export != nil {
+		if err := c.Export(newCtx, path, opts.Export); err != nil {
+			c.logger.Error("failed to export parameters before deleting them",
+				"error", err,
+				"path", path,
+			)
+			return nil, err
+		}
+	}
+
+	// delete params.
+	return c.delete(newCtx, names)
+}
+
+// protectedBy returns the prefix protecting the given (qualified) name, if
+// any.
+func (c *Client) protectedBy(name string) (string, bool) {
+	for _, p := range c.protected {
+		switch {
+		case p == "":
+			return "/", true
+		case name == p, strings.HasPrefix(name, p+"/"):
+			return p, true
+		}
+	}
+	return "", false
+}
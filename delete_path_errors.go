@@ -0,0 +1,36 @@
+package paramstore
+
+import "fmt"
+
+// ErrDeleteNotConfirmed is returned when a path is deleted without being
+// confirmed or forced.
+type ErrDeleteNotConfirmed struct {
+	Path string
+}
+
+func (e ErrDeleteNotConfirmed) Error() string {
+	return fmt.Sprintf("deleting %q must be confirmed with the path, or forced", e.Path)
+}
+
+// ErrTooManyParameters is returned when more params are found under a path
+// than are allowed to be deleted.
+type ErrTooManyParameters struct {
+	Path  string
+	Count int // The number of params found.
+	Max   int // The max number of params allowed.
+}
+
+func (e ErrTooManyParameters) Error() string {
+	return fmt.Sprintf("found %v parameters under %q, more than the max of %v", e.Count, e.Path, e.Max)
+}
+
+// ErrProtectedParameter is returned when a param under a protected prefix
+// would be deleted.
+type ErrProtectedParameter struct {
+	Name   string
+	Prefix string // The protected prefix.
+}
+
+func (e ErrProtectedParameter) Error() string {
+	return fmt.Sprintf("%q is protected by %q", e.Name, e.Prefix)
+}
@@ -0,0 +1,172 @@
+package paramstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_DeletePath(t *testing.T) {
+	store := Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/prod/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+		{Name: "/myapp/staging/host", Value: "db.staging", Type: ParameterTypeString},
+		{Name: "/myapp/staging/db/password", Value: "hunter3", Type: ParameterTypeSecureString},
+	}
+	tests := map[string]struct {
+		path      string
+		opts      DeletePathOptions
+		protected []string
+		dryRun    bool
+		want      int
+		err       error
+		remaining int
+	}{
+		"confirmed": {
+			path:      "/myapp/staging",
+			opts:      DeletePathOptions{Confirm: "/myapp/staging"},
+			want:      2,
+			remaining: 2,
+		},
+		"forced": {
+			path:      "/myapp",
+			opts:      DeletePathOptions{Force: true},
+			want:      4,
+			remaining: 0,
+		},
+		"not confirmed": {
+			path:      "/myapp/staging",
+			opts:      DeletePathOptions{Confirm: "/myapp/prod"},
+			err:       ErrDeleteNotConfirmed{"/myapp/staging"},
+			remaining: 4,
+		},
+		"too many params": {
+			path:      "/myapp",
+			opts:      DeletePathOptions{Force: true, MaxCount: 3},
+			err:       ErrTooManyParameters{"/myapp", 4, 3},
+			remaining: 4,
+		},
+		"protected prefix": {
+			path:      "/myapp",
+			opts:      DeletePathOptions{Force: true},
+			protected: []string{"/myapp/prod/"},
+			err:       ErrProtectedParameter{"/myapp/prod/db/password", "/myapp/prod"},
+			remaining: 4,
+		},
+		"unrelated protected prefix": {
+			path:      "/myapp/staging",
+			opts:      DeletePathOptions{Force: true},
+			protected: []string{"/myapp/prod", "/myapp/stag"},
+			want:      2,
+			remaining: 2,
+		},
+		"empty path": {
+			path:      "/other",
+			opts:      DeletePathOptions{Force: true},
+			remaining: 4,
+		},
+		"dry run": {
+			path:      "/myapp/staging",
+			opts:      DeletePathOptions{Force: true},
+			dryRun:    true,
+			want:      2,
+			remaining: 4,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 3, ssmsvc: mock, dryRun: tt.dryRun}
+			if err := WithProtectedPrefixes(tt.protected...)(c); err != nil {
+				t.Fatalf("WithProtectedPrefixes() returned an error; error=%v", err)
+			}
+			got, err := c.DeletePath(context.Background(), tt.path, tt.opts)
+			switch {
+			case tt.err == nil && err != nil:
+				t.Errorf("DeletePath() returned an error; error=%v", err)
+			case tt.err != nil && !errors.Is(err, tt.err):
+				t.Errorf("DeletePath() returned an unexpected error; want=%v, got=%v", tt.err, err)
+			}
+			if n := got.Count(ChangeActionDelete); n != tt.want {
+				t.Errorf("DeletePath() returned an unexpected number of deletes; want=%v, got=%v", tt.want, n)
+			}
+			remaining := 0
+			for _, p := range store {
+				if _, ok := s.Value(p.Name); ok {
+					remaining++
+				}
+			}
+			if remaining != tt.remaining {
+				t.Errorf("DeletePath() left an unexpected number of params; want=%v, got=%v", tt.remaining, remaining)
+			}
+		})
+	}
+}
+
+func Test_DeletePathWithNameTemplate(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/prod/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+		{Name: "/myapp/staging/host", Value: "db.staging", Type: ParameterTypeString},
+	})
+	c := &Client{
+		logger:        slog.Default(),
+		batchSize:     10,
+		ssmsvc:        mock,
+		nameTemplate:  "/myapp/{env}/{key}",
+		nameVariables: map[string]string{"env": "prod"},
+	}
+
+	// catch protected params, found via the template.
+	protected := *c
+	if err := WithProtectedPrefixes("/myapp/prod/db")(&protected); err != nil {
+		t.Fatalf("WithProtectedPrefixes() returned an error; error=%v", err)
+	}
+	_, err := protected.DeletePath(ctx, "/", DeletePathOptions{Force: true})
+	if want := (ErrProtectedParameter{"/db/password", "/myapp/prod/db"}); !errors.Is(err, want) {
+		t.Errorf("DeletePath() returned an unexpected error; want=%v, got=%v", want, err)
+	}
+
+	// delete params, found via the template.
+	got, err := c.DeletePath(ctx, "/", DeletePathOptions{Force: true})
+	if err != nil {
+		t.Fatalf("DeletePath() returned an error; error=%v", err)
+	}
+	if n := got.Count(ChangeActionDelete); n != 2 {
+		t.Errorf("DeletePath() returned an unexpected number of deletes; want=%v, got=%v", 2, n)
+	}
+	if want := []string{"/myapp/staging/host"}; !reflect.DeepEqual(want, s.Names()) {
+		t.Errorf("DeletePath() left unexpected params; want=%v, got=%v", want, s.Names())
+	}
+}
+
+func Test_DeletePathExport(t *testing.T) {
+	ctx := context.Background()
+	mock, _ := newMockSSMStore(Parameters{
+		{Name: "/myapp/staging/host", Value: "db.staging", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+
+	// delete params, exporting them first.
+	var archive bytes.Buffer
+	if _, err := c.DeletePath(ctx, "/myapp/staging", DeletePathOptions{Force: true, Export: &archive}); err != nil {
+		t.Fatalf("DeletePath() returned an error; error=%v", err)
+	}
+	if !strings.Contains(archive.String(), "db.staging") {
+		t.Errorf("DeletePath() didn't export the deleted params; got=%v", archive.String())
+	}
+
+	// restore params from the export.
+	result, err := c.Import(ctx, &archive, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() returned an error; error=%v", err)
+	}
+	if len(result.Imported) != 1 {
+		t.Errorf("Import() didn't restore the deleted params; got=%+v", result)
+	}
+}
@@ -0,0 +1,128 @@
+package paramstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Location is a path tree on a Client, compared by Diff().
+type Location struct {
+	Client *Client
+	Path   string
+}
+
+// Difference describes a parameter that differs between two locations.
+type Difference struct {
+	Name   string    // The name of the parameter, relative to the path of each location.
+	Kind   DriftKind // How the parameter differs.
+	Fields []string  // The fields that differ, for a metadata mismatch (tier or description).
+	Left   string    // The value in the left location, for a value mismatch; SecureString values are masked.
+	Right  string    // The value in the right location, for a value mismatch; SecureString values are masked.
+}
+
+// Differences is a slice of Difference.
+type Differences []Difference
+
+// Diff compares every parameter under the path of the left location with
+// every parameter under the path of the right location, which may be on
+// different clients (eg. for different regions or accounts), returning every
+// difference found. Names are compared relative to each path, so
+// "/app/staging/host" matches "/app/prod/host" when comparing "/app/staging"
+// with "/app/prod". SecureString values are compared, but are returned
+// masked, so neither they, nor a digest of them, are exposed by the result.
+func Diff(ctx context.Context, left, right Location) (out Differences, err error) {
+
+	// setup tracing.
+	newCtx, span := left.Client.tracer().Start(ctx, "Diff")
+	defer span.End()
+
+	// retrieve params from both locations.
+	l, err := left.parameters(newCtx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := right.parameters(newCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// determine names.
+	names := make([]string, 0, len(l)+len(r))
+	for n := range l {
+		names = append(names, n)
+	}
+	for n := range r {
+		if _, ok := l[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	// compare params.
+	for _, n := range names {
+		lp, inLeft := l[n]
+		rp, inRight := r[n]
+		switch {
+		case !inRight:
+			out = append(out, Difference{Name: n, Kind: DriftKindMissing})
+			continue
+		case !inLeft:
+			out = append(out, Difference{Name: n, Kind: DriftKindUnexpected})
+			continue
+		}
+		if lp.Type != rp.Type {
+			out = append(out, Difference{Name: n, Kind: DriftKindTypeMismatch})
+		}
+		secure := lp.Type == ParameterTypeSecureString || rp.Type == ParameterTypeSecureString
+		if lp.Value != rp.Value {
+			d := Difference{Name: n, Kind: DriftKindValueMismatch, Left: lp.Value, Right: rp.Value}
+			if secure {
+				d.Left, d.Right = maskedValue, maskedValue
+			}
+			out = append(out, d)
+		}
+		var fields []string
+		if lp.Tier != rp.Tier {
+			fields = append(fields, "tier")
+		}
+		if lp.Description != rp.Description {
+			fields = append(fields, "description")
+		}
+		if len(fields) > 0 {
+			out = append(out, Difference{Name: n, Kind: DriftKindMetadataMismatch, Fields: fields})
+		}
+	}
+	return out, nil
+}
+
+// parameters retrieves every parameter under the path of this location, with
+// metadata, keyed by name relative to the path.
+func (l Location) parameters(ctx context.Context) (map[string]Parameter, error) {
+
+	// retrieve params, with metadata.
+	decrypting := *l.Client
+	decrypting.withDecryption = true
+	found, err := decrypting.GetByPath(ctx, l.Path, true)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := l.Client.describeByPath(ctx, l.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// normalize names.
+	base := strings.TrimRight(l.Path, "/")
+	out := make(map[string]Parameter, len(found))
+	for _, p := range found {
+		if m, ok := metadata[p.Name]; ok {
+			p.Tier = ParameterTier(m.Tier)
+			p.Description = aws.ToString(m.Description)
+		}
+		out[strings.TrimPrefix(p.Name, base)] = p
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package paramstore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_Diff(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/staging/host", Value: "db.staging", Type: ParameterTypeString},
+		{Name: "/app/staging/name", Value: "myapp", Type: ParameterTypeString, Description: "The name."},
+		{Name: "/app/staging/password", Value: "hunter2", Type: ParameterTypeSecureString},
+		{Name: "/app/staging/only-staging", Value: "x", Type: ParameterTypeString},
+		{Name: "/app/prod/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/app/prod/name", Value: "myapp", Type: ParameterTypeString, Tier: ParameterTierAdvanced},
+		{Name: "/app/prod/password", Value: "hunter3", Type: ParameterTypeSecureString},
+		{Name: "/app/prod/only-prod", Value: "x", Type: ParameterTypeStringList},
+	}
+	tests := map[string]struct {
+		left, right string
+		other       bool
+		want        Differences
+	}{
+		"no differences": {
+			left:  "/app/staging",
+			right: "/app/staging/",
+		},
+		"compare paths": {
+			left:  "/app/staging",
+			right: "/app/prod",
+			want: Differences{
+				{Name: "/host", Kind: DriftKindValueMismatch, Left: "db.staging", Right: "db.prod"},
+				{Name: "/name", Kind: DriftKindMetadataMismatch, Fields: []string{"tier", "description"}},
+				{Name: "/only-prod", Kind: DriftKindUnexpected},
+				{Name: "/only-staging", Kind: DriftKindMissing},
+				{
+					Name:  "/password",
+					Kind:  DriftKindValueMismatch,
+					Left:  maskedValue,
+					Right: maskedValue,
+				},
+			},
+		},
+		"compare paths on different clients": {
+			left:  "/app/staging",
+			right: "/app/staging",
+			other: true,
+			want: Differences{
+				{Name: "/host", Kind: DriftKindMissing},
+				{Name: "/name", Kind: DriftKindMissing},
+				{Name: "/only-staging", Kind: DriftKindMissing},
+				{Name: "/password", Kind: DriftKindMissing},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(store)
+			left := newTestRegionClient("ap-southeast-2", mock)
+			right := left
+			if tt.other {
+				other, _ := newMockSSMStore(nil)
+				right = newTestRegionClient("us-east-1", other)
+			}
+			got, err := Diff(context.Background(), Location{left, tt.left}, Location{right, tt.right})
+			if err != nil {
+				t.Errorf("Diff() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Diff() returned unexpected differences;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
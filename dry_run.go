@@ -0,0 +1,143 @@
+package paramstore
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// dryRunKey is the context key used by ContextWithDryRun.
+type dryRunKey struct{}
+
+// ContextWithDryRun returns a copy of the given context that overrides the
+// WithDryRun option of the client for any call given the context.
+func ContextWithDryRun(ctx context.Context, dryRun bool) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, dryRun)
+}
+
+// isDryRun returns true if mutating operations shouldn't be made for the given
+// context.
+func (c *Client) isDryRun(ctx context.Context) bool {
+	if dryRun, ok := ctx.Value(dryRunKey{}).(bool); ok {
+		return dryRun
+	}
+	return c.dryRun
+}
+
+// Outcome is the outcome of a change to a single param made (or, in dry-run
+// mode, that would be made) by a mutating operation.
+type Outcome struct {
+	Name   string       // The name of the param.
+	Action ChangeAction // The action made to the param.
+	Err    error        // The error making the change, if any.
+}
+
+// Outcomes is a slice of Outcome.
+type Outcomes []Outcome
+
+// Failed returns the outcomes for the params that failed to change.
+func (outcomes Outcomes) Failed() (out Outcomes) {
+	for _, o := range outcomes {
+		if o.Err != nil {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// Count returns the number of outcomes with the given action, that didn't
+// fail.
+func (outcomes Outcomes) Count(action ChangeAction) (n int) {
+	for _, o := range outcomes {
+		if o.Action == action && o.Err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// current retrieves the latest version of each of the given (qualified) names
+// from paramstore, decrypted, keyed by name. Names that don't exist are left
+// out.
+func (c *Client) current(ctx context.Context, names []string) (map[string]Parameter, error) {
+	out := make(map[string]Parameter, len(names))
+	decrypt := true
+	for i := 0; i < len(names); i += c.batchSize {
+
+		// determine rolling batch size.
+		size := i + c.batchSize
+		if size > len(names) {
+			size = len(names)
+		}
+
+		// retrieve params.
+		in := &ssm.GetParametersInput{
+			Names:          names[i:size],
+			WithDecryption: &decrypt,
+		}
+		resp, err := c.ssmsvc.GetParameters(ctx, in)
+		if err != nil {
+			c.logger.Error("failed to get current parameters",
+				"error", err,
+				"names", in.Names,
+			)
+			return nil, err
+		}
+		for _, p := range resp.Parameters {
+			out[*p.Name] = newParameter(*p.Name, p)
+		}
+	}
+	return out, nil
+}
+
+// snapshot retrieves the latest version of each of the given (qualified) names
+// from paramstore, in the same way as current(), including the tier,
+// description and KMS key of each param.
+func (c *Client) snapshot(ctx context.Context, names []string) (map[string]Parameter, error) {
+
+	// retrieve current params.
+	current, err := c.current(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	var existing []string
+	for _, n := range names {
+		if _, ok := current[n]; ok {
+			existing = append(existing, n)
+		}
+	}
+
+	// retrieve metadata in batches.
+	for i := 0; i < len(existing); i += c.batchSize {
+
+		// determine rolling batch size.
+		size := i + c.batchSize
+		if size > len(existing) {
+			size = len(existing)
+		}
+
+		// retrieve metadata.
+		found, err := c.describe(ctx, types.ParameterStringFilter{
+			Key:    aws.String("Name"),
+			Option: aws.String("Equals"),
+			Values: existing[i:size],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range existing[i:size] {
+			m, ok := found[c.unqualify(n)]
+			if !ok {
+				continue
+			}
+			p := current[n]
+			p.Tier = ParameterTier(m.Tier)
+			p.Description = aws.ToString(m.Description)
+			p.KeyId = aws.ToString(m.KeyId)
+			current[n] = p
+		}
+	}
+	return current, nil
+}
@@ -0,0 +1,110 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_PutWithOutcomes(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/app/name", Value: "myapp", Type: ParameterTypeString},
+	}
+	params := Parameters{
+		{Name: "/app/host", Value: "db.staging", Type: ParameterTypeString, Overwrite: true},
+		{Name: "/app/name", Value: "myapp", Type: ParameterTypeString, Overwrite: true},
+		{Name: "/app/new", Value: "hello", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		dryRun bool
+		ctx    context.Context
+		want   Outcomes
+		values map[string]string
+	}{
+		"put params": {
+			want: Outcomes{
+				{Name: "/app/host", Action: ChangeActionUpdate},
+				{Name: "/app/name", Action: ChangeActionUpdate},
+				{Name: "/app/new", Action: ChangeActionCreate},
+			},
+			values: map[string]string{"/app/host": "db.staging", "/app/new": "hello"},
+		},
+		"dry run with client option": {
+			dryRun: true,
+			want: Outcomes{
+				{Name: "/app/host", Action: ChangeActionUpdate},
+				{Name: "/app/name", Action: ChangeActionNoop},
+				{Name: "/app/new", Action: ChangeActionCreate},
+			},
+			values: map[string]string{"/app/host": "db.prod"},
+		},
+		"dry run with context": {
+			ctx: ContextWithDryRun(context.Background(), true),
+			want: Outcomes{
+				{Name: "/app/host", Action: ChangeActionUpdate},
+				{Name: "/app/name", Action: ChangeActionNoop},
+				{Name: "/app/new", Action: ChangeActionCreate},
+			},
+			values: map[string]string{"/app/host": "db.prod"},
+		},
+		"context overrides client option": {
+			dryRun: true,
+			ctx:    ContextWithDryRun(context.Background(), false),
+			want: Outcomes{
+				{Name: "/app/host", Action: ChangeActionUpdate},
+				{Name: "/app/name", Action: ChangeActionUpdate},
+				{Name: "/app/new", Action: ChangeActionCreate},
+			},
+			values: map[string]string{"/app/host": "db.staging"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 2, ssmsvc: mock, dryRun: tt.dryRun}
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, err := c.PutWithOutcomes(ctx, params)
+			if err != nil {
+				t.Errorf("PutWithOutcomes() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("PutWithOutcomes() returned unexpected outcomes;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+			for n, want := range tt.values {
+				if got, _ := s.Value(n); got != want {
+					t.Errorf("PutWithOutcomes() left an unexpected value for %v; want=%v, got=%v", n, want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_DeleteWithOutcomes(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+	}
+	want := Outcomes{
+		{Name: "/app/host", Action: ChangeActionDelete},
+		{Name: "/app/missing", Action: ChangeActionMissing, Err: ErrInvalidParameter{"/app/missing"}},
+	}
+	for _, dryRun := range []bool{false, true} {
+		mock, s := newMockSSMStore(store)
+		c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, dryRun: dryRun}
+		got, err := c.DeleteWithOutcomes(context.Background(), "/app/host", "/app/missing")
+		if err == nil {
+			t.Errorf("DeleteWithOutcomes() didn't return an error for a missing param; dryRun=%v", dryRun)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("DeleteWithOutcomes() returned unexpected outcomes; dryRun=%v\nwant=%+v\ngot=%+v\n", dryRun, want, got)
+		}
+		if _, exists := s.Value("/app/host"); exists != dryRun {
+			t.Errorf("DeleteWithOutcomes() changed the store unexpectedly; dryRun=%v", dryRun)
+		}
+	}
+}
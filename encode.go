@@ -0,0 +1,211 @@
+package paramstore
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	multierror "github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format that Parameters can be encoded into.
+type Format string
+
+const (
+	FormatJSON   Format = "json"   // A JSON array of parameters.
+	FormatYAML   Format = "yaml"   // A YAML list of parameters.
+	FormatTOML   Format = "toml"   // A TOML array of parameter tables.
+	FormatDotenv Format = "dotenv" // A .env file, with one KEY="value" line per parameter.
+	FormatShell  Format = "shell"  // A shell script, with one export KEY='value' line per parameter.
+	FormatCSV    Format = "csv"    // A CSV file, with a header row.
+	FormatTable  Format = "table"  // An aligned table, with a header row.
+)
+
+// Formats is every Format that Parameters can be encoded into.
+var Formats = []Format{
+	FormatJSON,
+	FormatYAML,
+	FormatTOML,
+	FormatDotenv,
+	FormatShell,
+	FormatCSV,
+	FormatTable,
+}
+
+// ParseFormat converts the given string into a Format.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+	return "", ErrUnknownFormat{s}
+}
+
+// the value used in place of a SecureString value that is masked.
+const maskedValue = "********"
+
+// EncodeOptions configures how Parameters are encoded.
+type EncodeOptions struct {
+	Reveal    bool                     // If true, SecureString values aren't redacted.
+	Redaction RedactionPolicy          // How SecureString values are redacted; defaults to RedactionMask.
+	KeyFunc   func(name string) string // Converts names into keys for the dotenv and shell formats; defaults to EnvKey.
+}
+
+// encodedParameter is a Parameter, as it's written by the structured formats.
+type encodedParameter struct {
+	Name    string        `json:"name"              yaml:"name"              toml:"name"`
+	Value   string        `json:"value"             yaml:"value"             toml:"value"`
+	Type    ParameterType `json:"type,omitempty"    yaml:"type,omitempty"    toml:"type,omitempty"`
+	Tier    ParameterTier `json:"tier,omitempty"    yaml:"tier,omitempty"    toml:"tier,omitempty"`
+	Version int64         `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitzero"`
+
+	Description string `json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
+}
+
+// Encode writes the parameters to the given writer in the given format.
+// SecureString values are redacted, using opts.Redaction, unless opts.Reveal
+// is true.
+func (parameters Parameters) Encode(w io.Writer, format Format, opts EncodeOptions) error {
+
+	// redact values.
+	encoded := make([]encodedParameter, len(parameters))
+	for i, p := range parameters {
+		encoded[i] = encodedParameter{
+			Name:    p.Name,
+			Value:   p.Value,
+			Type:    p.Type,
+			Tier:    p.Tier,
+			Version: p.Version,
+
+			Description: p.Description,
+		}
+		if p.Type == ParameterTypeSecureString && !opts.Reveal {
+			encoded[i].Value = opts.Redaction.redact(p.Value)
+		}
+	}
+	key := opts.KeyFunc
+	if key == nil {
+		key = EnvKey
+	}
+
+	// encode values.
+	switch format {
+	case FormatJSON:
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(encoded)
+
+	case FormatYAML:
+		e := yaml.NewEncoder(w)
+		e.SetIndent(2)
+		if err := e.Encode(encoded); err != nil {
+			return err
+		}
+		return e.Close()
+
+	case FormatTOML:
+		return toml.NewEncoder(w).Encode(map[string][]encodedParameter{"parameters": encoded})
+
+	case FormatDotenv:
+		for _, p := range encoded {
+			if _, err := fmt.Fprintf(w, "%v=%v\n", key(p.Name), quoteDotenv(p.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatShell:
+		for _, p := range encoded {
+			if _, err := fmt.Fprintf(w, "export %v=%v\n", key(p.Name), quoteShell(p.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"name", "type", "value"}); err != nil {
+			return err
+		}
+		for _, p := range encoded {
+			if err := cw.Write([]string{p.Name, string(p.Type), p.Value}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case FormatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tVALUE")
+		for _, p := range encoded {
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", p.Name, p.Type, strings.ReplaceAll(p.Value, "\n", "\\n"))
+		}
+		return tw.Flush()
+	}
+	return ErrUnknownFormat{string(format)}
+}
+
+// DecodeParameters reads parameters from the given reader, in one of the
+// structured formats written by Encode (json, yaml or toml). SecureString
+// parameters with a redacted value, written by Encode without revealing
+// values, are rejected.
+func DecodeParameters(r io.Reader, format Format) (out Parameters, errs error) {
+
+	// decode values.
+	var decoded []encodedParameter
+	switch format {
+	case FormatJSON:
+		if err := json.NewDecoder(r).Decode(&decoded); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.NewDecoder(r).Decode(&decoded); err != nil && err != io.EOF {
+			return nil, err
+		}
+	case FormatTOML:
+		var doc map[string][]encodedParameter
+		if _, err := toml.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, err
+		}
+		decoded = doc["parameters"]
+	default:
+		return nil, ErrUnknownFormat{string(format)}
+	}
+
+	// convert values.
+	out = make(Parameters, len(decoded))
+	for i, p := range decoded {
+		if p.Type == ParameterTypeSecureString && isRedacted(p.Value) {
+			errs = multierror.Append(errs, ErrRedactedValue{p.Name})
+		}
+		out[i] = Parameter{
+			Name:        p.Name,
+			Value:       p.Value,
+			Type:        p.Type,
+			Tier:        p.Tier,
+			Description: p.Description,
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return out, nil
+}
+
+// quoteDotenv quotes the given value for use in a .env file.
+func quoteDotenv(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, `$`, `\$`)
+	return `"` + r.Replace(value) + `"`
+}
+
+// quoteShell quotes the given value for use in a POSIX shell.
+func quoteShell(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,23 @@
+package paramstore
+
+import "fmt"
+
+// ErrUnknownFormat is returned when a format isn't one of Formats.
+type ErrUnknownFormat struct {
+	format string
+}
+
+func (e ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("unknown format %q", e.format)
+}
+
+// ErrRedactedValue is returned when the value of a SecureString parameter is a
+// redacted placeholder (eg. "********"), such as from a file written without
+// revealing values, which would overwrite the real value if uploaded.
+type ErrRedactedValue struct {
+	Name string
+}
+
+func (e ErrRedactedValue) Error() string {
+	return fmt.Sprintf("the value of %q is redacted; write the file with values revealed", e.Name)
+}
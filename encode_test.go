@@ -0,0 +1,189 @@
+package paramstore
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_Encode(t *testing.T) {
+	tests := map[string]struct {
+		format Format
+		opts   EncodeOptions
+		want   string
+		err    string
+	}{
+		"json": {
+			format: FormatJSON,
+			want: `[
+  {
+    "name": "/hello",
+    "value": "********",
+    "type": "SecureString"
+  },
+  {
+    "name": "/world",
+    "value": "this is plain text",
+    "type": "String"
+  },
+  {
+    "name": "/test",
+    "value": "this,is,a,comma,list",
+    "type": "StringList"
+  }
+]
+`,
+		},
+		"yaml": {
+			format: FormatYAML,
+			want: `- name: /hello
+  value: '********'
+  type: SecureString
+- name: /world
+  value: this is plain text
+  type: String
+- name: /test
+  value: this,is,a,comma,list
+  type: StringList
+`,
+		},
+		"toml": {
+			format: FormatTOML,
+			want: `[[parameters]]
+  name = "/hello"
+  value = "********"
+  type = "SecureString"
+
+[[parameters]]
+  name = "/world"
+  value = "this is plain text"
+  type = "String"
+
+[[parameters]]
+  name = "/test"
+  value = "this,is,a,comma,list"
+  type = "StringList"
+`,
+		},
+		"dotenv": {
+			format: FormatDotenv,
+			want: `HELLO="********"
+WORLD="this is plain text"
+TEST="this,is,a,comma,list"
+`,
+		},
+		"shell (revealed)": {
+			format: FormatShell,
+			opts:   EncodeOptions{Reveal: true},
+			want: `export HELLO='this is (possibly) hidden'
+export WORLD='this is plain text'
+export TEST='this,is,a,comma,list'
+`,
+		},
+		"csv": {
+			format: FormatCSV,
+			want: `name,type,value
+/hello,SecureString,********
+/world,String,this is plain text
+/test,StringList,"this,is,a,comma,list"
+`,
+		},
+		"table": {
+			format: FormatTable,
+			want: `NAME    TYPE          VALUE
+/hello  SecureString  ********
+/world  String        this is plain text
+/test   StringList    this,is,a,comma,list
+`,
+		},
+		"dotenv (custom keys)": {
+			format: FormatDotenv,
+			opts:   EncodeOptions{KeyFunc: func(name string) string { return "APP" + EnvKey(name) }},
+			want: `APPHELLO="********"
+APPWORLD="this is plain text"
+APPTEST="this,is,a,comma,list"
+`,
+		},
+		"catch unknown format": {
+			format: "xml",
+			err:    `unknown format "xml"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := validTestdata.toParameters().Encode(&buf, tt.format, tt.opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Encode() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Encode() returned an error; error=%v", err)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Encode() returned unexpected output;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_DecodeParameters(t *testing.T) {
+	want := validTestdata.toParameters()
+	want[1].Tier = ParameterTierAdvanced
+	want[1].Description = "a description"
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := want.Encode(&buf, format, EncodeOptions{Reveal: true}); err != nil {
+				t.Fatalf("Encode() returned an error; error=%v", err)
+			}
+			got, err := DecodeParameters(&buf, format)
+			if err != nil {
+				t.Errorf("DecodeParameters() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("DecodeParameters() returned unexpected parameters;\nwant=%+v\ngot=%+v\n", want, got)
+			}
+		})
+	}
+	// catch redacted values, written without revealing values.
+	for _, policy := range []RedactionPolicy{RedactionMask, RedactionHash, RedactionLength} {
+		t.Run("redacted/"+string(policy), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := want.Encode(&buf, FormatJSON, EncodeOptions{Redaction: policy}); err != nil {
+				t.Fatalf("Encode() returned an error; error=%v", err)
+			}
+			if _, err := DecodeParameters(&buf, FormatJSON); !errors.As(err, &ErrRedactedValue{}) {
+				t.Errorf("DecodeParameters() returned an unexpected error; want=ErrRedactedValue, got=%v", err)
+			}
+		})
+	}
+	if _, err := DecodeParameters(&bytes.Buffer{}, FormatDotenv); err == nil {
+		t.Errorf("DecodeParameters() didn't return an error for an unsupported format")
+	}
+}
+
+func Test_quote(t *testing.T) {
+	if got, want := quoteDotenv("a \"b\" $c\nd\\"), `"a \"b\" \$c\nd\\"`; got != want {
+		t.Errorf("quoteDotenv() returned unexpected value; want=%v, got=%v", want, got)
+	}
+	if got, want := quoteShell("it's"), `'it'"'"'s'`; got != want {
+		t.Errorf("quoteShell() returned unexpected value; want=%v, got=%v", want, got)
+	}
+}
+
+func Test_ParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		if got, err := ParseFormat(string(f)); err != nil || got != f {
+			t.Errorf("ParseFormat() returned unexpected format; want=%v, got=%v, err=%v", f, got, err)
+		}
+	}
+	if _, err := ParseFormat("XML"); err == nil {
+		t.Errorf("ParseFormat() didn't return an error for an unknown format")
+	}
+}
@@ -0,0 +1,355 @@
+package paramstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Encrypter encrypts values before they leave the process, and decrypts them
+// after they're retrieved, on top of any encryption done by AWS SSM Parameter
+// Store. Encrypted values are stored with a header naming the key used, such
+// as "paramstore+enc:<key id>:<ciphertext>", so keys can be rotated. The
+// (qualified) name of the param is given to bind the ciphertext to the param,
+// so it can't be copied to another param and still be decrypted.
+type Encrypter interface {
+	KeyID() string                                                                      // The id of the key used to encrypt new values.
+	Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error)         // Encrypts the value of the given param with the current key.
+	Decrypt(ctx context.Context, name, keyID string, ciphertext []byte) ([]byte, error) // Decrypts the value of the given param with the given key.
+}
+
+// the prefix of the header written in front of every encrypted value.
+const encryptedHeaderPrefix = "paramstore+enc:"
+
+// aesGCMEncrypter is an Encrypter that uses AES-GCM with local keys.
+type aesGCMEncrypter struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewAESGCMEncrypter returns an Encrypter that uses AES-GCM with the given
+// keys, keyed by id, each of which must be 16, 24 or 32 bytes. New values are
+// encrypted with the current key, while values encrypted with any of the keys
+// can be decrypted, so older keys can be kept while they're rotated out.
+func NewAESGCMEncrypter(current string, keys map[string][]byte) (Encrypter, error) {
+	e := &aesGCMEncrypter{current: current, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" {
+			return nil, ErrInvalidKey{id, "key id cannot be empty"}
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, ErrInvalidKey{id, err.Error()}
+		}
+		e.keys[id] = aead
+	}
+	if _, ok := e.keys[current]; !ok {
+		return nil, ErrUnknownKey{current}
+	}
+	return e, nil
+}
+
+// newAEAD returns an AES-GCM cipher for the given key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData returns the additional data authenticated with the value of
+// the given param, binding the ciphertext to the name of the param and the id
+// of the key used.
+func additionalData(name, keyID string) []byte {
+	return []byte(name + "\x00" + keyID)
+}
+
+// seal encrypts the given plaintext with a random nonce, which is written in
+// front of the ciphertext.
+func seal(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// open decrypts the given ciphertext, written by seal.
+func open(aead cipher.AEAD, ciphertext, additional []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext{"ciphertext is too short"}
+	}
+	n := aead.NonceSize()
+	return aead.Open(nil, ciphertext[:n], ciphertext[n:], additional)
+}
+
+// KeyID returns the id of the current key.
+func (e *aesGCMEncrypter) KeyID() string {
+	return e.current
+}
+
+// Encrypt encrypts the value of the given param with the current key.
+func (e *aesGCMEncrypter) Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error) {
+	return seal(e.keys[e.current], plaintext, additionalData(name, e.current))
+}
+
+// Decrypt decrypts the value of the given param with the given key.
+func (e *aesGCMEncrypter) Decrypt(ctx context.Context, name, keyID string, ciphertext []byte) ([]byte, error) {
+	aead, ok := e.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey{keyID}
+	}
+	return open(aead, ciphertext, additionalData(name, keyID))
+}
+
+// DataKeyProvider generates data keys wrapped by a master key, and unwraps
+// them, such as the GenerateDataKey and Decrypt calls of AWS KMS.
+type DataKeyProvider interface {
+	KeyID() string                                                                    // The id of the master key used to wrap new data keys.
+	GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, err error)       // Returns a new 32 byte data key, and the data key wrapped by the master key.
+	DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) // Unwraps the given data key with the given master key.
+}
+
+// envelopeEncrypter is an Encrypter that encrypts each value with its own data
+// key, stored alongside the value, wrapped by a master key.
+type envelopeEncrypter struct {
+	provider DataKeyProvider
+}
+
+// NewEnvelopeEncrypter returns an Encrypter that encrypts each value with AES-GCM
+// using a new data key from the given provider, storing the wrapped data key
+// in front of the ciphertext, so the master key never leaves the provider.
+func NewEnvelopeEncrypter(provider DataKeyProvider) Encrypter {
+	return &envelopeEncrypter{provider}
+}
+
+// KeyID returns the id of the master key used by the provider.
+func (e *envelopeEncrypter) KeyID() string {
+	return e.provider.KeyID()
+}
+
+// Encrypt encrypts the value of the given param with a new data key.
+func (e *envelopeEncrypter) Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error) {
+	key, wrapped, err := e.provider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) > 0xffff {
+		return nil, ErrInvalidKey{e.provider.KeyID(), "wrapped data key is too large"}
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, ErrInvalidKey{e.provider.KeyID(), err.Error()}
+	}
+	sealed, err := seal(aead, plaintext, additionalData(name, e.provider.KeyID()))
+	if err != nil {
+		return nil, err
+	}
+	out := binary.BigEndian.AppendUint16(nil, uint16(len(wrapped)))
+	out = append(out, wrapped...)
+	return append(out, sealed...), nil
+}
+
+// Decrypt unwraps the data key stored in front of the value of the given
+// param, then decrypts the value with it.
+func (e *envelopeEncrypter) Decrypt(ctx context.Context, name, keyID string, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 2 {
+		return nil, ErrInvalidCiphertext{"ciphertext is too short"}
+	}
+	n := int(binary.BigEndian.Uint16(ciphertext))
+	if len(ciphertext) < 2+n {
+		return nil, ErrInvalidCiphertext{"ciphertext is too short"}
+	}
+	key, err := e.provider.DecryptDataKey(ctx, keyID, ciphertext[2:2+n])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, ErrInvalidKey{keyID, err.Error()}
+	}
+	return open(aead, ciphertext[2+n:], additionalData(name, keyID))
+}
+
+// parseEncrypted parses the header of the given value, returning false if the
+// value isn't encrypted.
+// NOTE: key ids can contain ":" (eg. KMS key ARNs), while the ciphertext is
+// base64 encoded, so the ciphertext starts after the last ":".
+func parseEncrypted(value string) (keyID string, ciphertext []byte, ok bool, err error) {
+	rest, ok := strings.CutPrefix(value, encryptedHeaderPrefix)
+	if !ok {
+		return "", nil, false, nil
+	}
+	i := strings.LastIndex(rest, ":")
+	if i <= 0 {
+		return "", nil, false, nil
+	}
+	ciphertext, err = base64.StdEncoding.DecodeString(rest[i+1:])
+	return rest[:i], ciphertext, true, err
+}
+
+// encryptAll encrypts the value of each of the given (qualified) params with
+// the encrypter configured for this client, if any.
+func (c *Client) encryptAll(ctx context.Context, qualified Parameters) (errs error) {
+	if c.encrypter == nil {
+		return nil
+	}
+	for i, p := range qualified {
+		if p.Value == "" {
+			continue
+		}
+		ciphertext, err := c.encrypter.Encrypt(ctx, p.Name, []byte(p.Value))
+		if err != nil {
+			c.logger.Error("failed to encrypt parameter",
+				"error", err,
+				"name", p.Name,
+			)
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		qualified[i].Value = encryptedHeaderPrefix + c.encrypter.KeyID() + ":" + base64.StdEncoding.EncodeToString(ciphertext)
+	}
+	return errs
+}
+
+// decryptAll decrypts the value of each of the given params that has a
+// header, with the encrypter configured for this client. The qualified name of
+// each param is given in qualified, or nil if the params already have
+// qualified names. Values without a header are rejected if this client
+// requires encryption.
+func (c *Client) decryptAll(ctx context.Context, params Parameters, qualified []string) (errs error) {
+	for i, p := range params {
+		name := p.Name
+		if qualified != nil {
+			name = qualified[i]
+		}
+		keyID, ciphertext, ok, err := parseEncrypted(p.Value)
+		if !ok && (!c.requireEncryption || c.encrypter == nil) {
+			continue
+		}
+		var plaintext []byte
+		switch {
+		case err != nil:
+		case !ok:
+			err = ErrNotEncrypted{}
+		case c.encrypter == nil:
+			err = ErrEncrypterNotConfigured{}
+		default:
+			plaintext, err = c.encrypter.Decrypt(ctx, name, keyID, ciphertext)
+		}
+		if err != nil {
+			c.logger.Error("failed to decrypt parameter",
+				"error", err,
+				"name", p.Name,
+				"keyId", keyID,
+			)
+			errs = multierror.Append(errs, ErrDecryptionFailed{p.Name, err})
+			continue
+		}
+		params[i].Value = string(plaintext)
+	}
+	return errs
+}
+
+// Reencrypt re-encrypts every param under the given path that was encrypted
+// with a key other than the current key of the encrypter configured for this
+// client, returning the outcome for each param re-encrypted. The older keys
+// must still be available to the encrypter.
+func (c *Client) Reencrypt(ctx context.Context, path string) (Outcomes, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Reencrypt")
+	defer span.End()
+
+	// retrieve params, with metadata.
+	if c.encrypter == nil {
+		return nil, ErrEncrypterNotConfigured{}
+	}
+	metadata, err := c.describeByPath(newCtx, path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for n := range metadata {
+		if c.largeValues && isChunk(n) {
+			continue
+		}
+		qualified, err := c.qualify(n)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, qualified)
+	}
+	current, err := c.snapshot(newCtx, names)
+	if err != nil {
+		return nil, err
+	}
+	params := make(Parameters, 0, len(current))
+	for _, p := range current {
+		params = append(params, p)
+	}
+	if c.largeValues {
+		if err := c.assemble(newCtx, params, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// decrypt params encrypted with an older key.
+	var errs error
+	var rotate Parameters
+	for _, p := range params {
+		keyID, ciphertext, ok, err := parseEncrypted(p.Value)
+		if !ok || keyID == c.encrypter.KeyID() {
+			continue
+		}
+		var plaintext []byte
+		if err == nil {
+			plaintext, err = c.encrypter.Decrypt(newCtx, p.Name, keyID, ciphertext)
+		}
+		if err != nil {
+			errs = multierror.Append(errs, ErrDecryptionFailed{c.unqualify(p.Name), err})
+			continue
+		}
+		rotate = append(rotate, Parameter{
+			Name:        c.unqualify(p.Name),
+			Value:       string(plaintext),
+			Type:        p.Type,
+			Tier:        p.Tier,
+			Description: p.Description,
+			KeyId:       p.KeyId,
+			Codec:       CodecPlain, // the value is already encoded.
+			Overwrite:   true,
+		})
+	}
+	if errs != nil || len(rotate) == 0 {
+		return nil, errs
+	}
+	sort.Slice(rotate, func(i, j int) bool { return rotate[i].Name < rotate[j].Name })
+
+	// log params, without re-encrypting them.
+	if c.isDryRun(newCtx) {
+		out := make(Outcomes, len(rotate))
+		for i, p := range rotate {
+			c.logger.Info("dry run: would re-encrypt parameter",
+				"name", p.Name,
+				"keyId", c.encrypter.KeyID(),
+			)
+			out[i] = Outcome{Name: p.Name, Action: ChangeActionUpdate}
+		}
+		return out, nil
+	}
+
+	// re-encrypt params with the current key; the current values always match
+	// once decrypted, so they're never skipped as unchanged.
+	rotating := *c
+	rotating.idempotentPuts = false
+	return rotating.put(newCtx, rotate)
+}
@@ -0,0 +1,63 @@
+package paramstore
+
+import "fmt"
+
+// ErrInvalidKey is returned when an encryption key can't be used.
+type ErrInvalidKey struct {
+	keyID  string
+	reason string
+}
+
+func (e ErrInvalidKey) Error() string {
+	return fmt.Sprintf("invalid encryption key %q: %v", e.keyID, e.reason)
+}
+
+// ErrUnknownKey is returned when a value was encrypted with a key that isn't
+// available to the encrypter.
+type ErrUnknownKey struct {
+	keyID string
+}
+
+func (e ErrUnknownKey) Error() string {
+	return fmt.Sprintf("encryption key %q is unknown", e.keyID)
+}
+
+// ErrInvalidCiphertext is returned when an encrypted value is malformed.
+type ErrInvalidCiphertext struct {
+	reason string
+}
+
+func (e ErrInvalidCiphertext) Error() string {
+	return fmt.Sprintf("invalid ciphertext: %v", e.reason)
+}
+
+// ErrEncrypterNotConfigured is returned when a value is encrypted, but no
+// encrypter was given to the client.
+type ErrEncrypterNotConfigured struct{}
+
+func (e ErrEncrypterNotConfigured) Error() string {
+	return "no encrypter is configured; use WithEncrypter"
+}
+
+// ErrNotEncrypted is returned when a value was retrieved without being
+// encrypted, but the client requires encryption.
+type ErrNotEncrypted struct{}
+
+func (e ErrNotEncrypted) Error() string {
+	return "value is not encrypted"
+}
+
+// ErrDecryptionFailed is returned when the value of a param can't be
+// decrypted.
+type ErrDecryptionFailed struct {
+	Name string
+	err  error
+}
+
+func (e ErrDecryptionFailed) Error() string {
+	return fmt.Sprintf("failed to decrypt %q: %v", e.Name, e.err)
+}
+
+func (e ErrDecryptionFailed) Unwrap() error {
+	return e.err
+}
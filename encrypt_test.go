@@ -0,0 +1,253 @@
+package paramstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// fakeDataKeyProvider is a DataKeyProvider that "wraps" data keys by
+// reversing them.
+type fakeDataKeyProvider struct {
+	keyID string
+}
+
+func (p fakeDataKeyProvider) KeyID() string {
+	return p.keyID
+}
+
+func (p fakeDataKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	key := bytes.Repeat([]byte{0x2a}, 16)
+	key = append(key, bytes.Repeat([]byte{0x07}, 16)...)
+	return key, reverse(key), nil
+}
+
+func (p fakeDataKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, ErrUnknownKey{keyID}
+	}
+	return reverse(wrapped), nil
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+func Test_Encrypter(t *testing.T) {
+	aesGCM, err := NewAESGCMEncrypter("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	if err != nil {
+		t.Fatalf("NewAESGCMEncrypter() returned an error; error=%v", err)
+	}
+	tests := map[string]Encrypter{
+		"aes-gcm":  aesGCM,
+		"envelope": NewEnvelopeEncrypter(fakeDataKeyProvider{"arn:aws:kms:ap-southeast-2:123456789012:key/abc"}),
+	}
+	for name, e := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ciphertext, err := e.Encrypt(ctx, "/myapp/db/password", []byte("hunter2"))
+			if err != nil {
+				t.Fatalf("Encrypt() returned an error; error=%v", err)
+			}
+			if bytes.Contains(ciphertext, []byte("hunter2")) {
+				t.Errorf("Encrypt() returned the plaintext; got=%q", ciphertext)
+			}
+			got, err := e.Decrypt(ctx, "/myapp/db/password", e.KeyID(), ciphertext)
+			if err != nil {
+				t.Fatalf("Decrypt() returned an error; error=%v", err)
+			}
+			if string(got) != "hunter2" {
+				t.Errorf("Decrypt() returned an unexpected value; want=%q, got=%q", "hunter2", got)
+			}
+
+			// catch ciphertext copied to another param.
+			if _, err := e.Decrypt(ctx, "/myapp/db/other", e.KeyID(), ciphertext); err == nil {
+				t.Errorf("Decrypt() didn't return an error for ciphertext of another param")
+			}
+
+			// catch tampered ciphertext.
+			ciphertext[len(ciphertext)-1] ^= 0xff
+			if _, err := e.Decrypt(ctx, "/myapp/db/password", e.KeyID(), ciphertext); err == nil {
+				t.Errorf("Decrypt() didn't return an error for tampered ciphertext")
+			}
+		})
+	}
+}
+
+func Test_NewAESGCMEncrypter(t *testing.T) {
+	tests := map[string]struct {
+		current string
+		keys    map[string][]byte
+		want    error
+	}{
+		"valid keys": {
+			current: "k2",
+			keys:    map[string][]byte{"k1": make([]byte, 16), "k2": make([]byte, 32)},
+		},
+		"invalid key length": {
+			current: "k1",
+			keys:    map[string][]byte{"k1": make([]byte, 20)},
+			want:    ErrInvalidKey{},
+		},
+		"empty key id": {
+			current: "",
+			keys:    map[string][]byte{"": make([]byte, 32)},
+			want:    ErrInvalidKey{},
+		},
+		"unknown current key": {
+			current: "k2",
+			keys:    map[string][]byte{"k1": make([]byte, 32)},
+			want:    ErrUnknownKey{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewAESGCMEncrypter(tt.current, tt.keys)
+			switch tt.want.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("NewAESGCMEncrypter() returned an error; error=%v", err)
+				}
+			case ErrInvalidKey:
+				if !errors.As(err, &ErrInvalidKey{}) {
+					t.Errorf("NewAESGCMEncrypter() returned an unexpected error; want=ErrInvalidKey, got=%v", err)
+				}
+			case ErrUnknownKey:
+				if !errors.As(err, &ErrUnknownKey{}) {
+					t.Errorf("NewAESGCMEncrypter() returned an unexpected error; want=ErrUnknownKey, got=%v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_Encryption(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/other", Value: "from another tool", Type: ParameterTypeString},
+	})
+	keys := map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 32),
+	}
+	old, err := NewAESGCMEncrypter("k1", keys)
+	if err != nil {
+		t.Fatalf("NewAESGCMEncrypter() returned an error; error=%v", err)
+	}
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, encrypter: old}
+	params := Parameters{
+		{Name: "/myapp/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+		{Name: "/myapp/config", Value: `{"feature":"enabled"}`, Type: ParameterTypeString, Codec: CodecGzip},
+	}
+	if err := c.Put(ctx, params); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+
+	// check stored values.
+	for _, p := range params {
+		v, _ := s.Value(p.Name)
+		if !strings.HasPrefix(v, encryptedHeaderPrefix+"k1:") {
+			t.Errorf("Put() stored an unexpected value for %v; got=%v", p.Name, v)
+		}
+	}
+
+	// check retrieved values.
+	got, err := c.GetMultiple(ctx, append(params.ToSliceString(), "/myapp/other")...)
+	if err != nil {
+		t.Fatalf("GetMultiple() returned an error; error=%v", err)
+	}
+	for i, p := range params {
+		if got[i].Value != p.Value {
+			t.Errorf("GetMultiple() returned an unexpected value for %v; want=%q, got=%q", p.Name, p.Value, got[i].Value)
+		}
+	}
+
+	// check idempotent puts compare decrypted values.
+	c.idempotentPuts = true
+	before, _ := s.Value("/myapp/db/password")
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/db/password", Value: "hunter2", Type: ParameterTypeSecureString, Overwrite: true}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if after, _ := s.Value("/myapp/db/password"); after != before {
+		t.Errorf("Put() re-uploaded an unchanged value")
+	}
+
+	// rotate keys.
+	c.encrypter, err = NewAESGCMEncrypter("k2", keys)
+	if err != nil {
+		t.Fatalf("NewAESGCMEncrypter() returned an error; error=%v", err)
+	}
+	outcomes, err := c.Reencrypt(ctx, "/myapp")
+	if err != nil {
+		t.Fatalf("Reencrypt() returned an error; error=%v", err)
+	}
+	if len(outcomes) != len(params) {
+		t.Errorf("Reencrypt() returned an unexpected number of outcomes; want=%v, got=%v", len(params), len(outcomes))
+	}
+	for _, p := range params {
+		v, _ := s.Value(p.Name)
+		if !strings.HasPrefix(v, encryptedHeaderPrefix+"k2:") {
+			t.Errorf("Reencrypt() stored an unexpected value for %v; got=%v", p.Name, v)
+		}
+	}
+	got, err = c.GetMultiple(ctx, params.ToSliceString()...)
+	if err != nil {
+		t.Fatalf("GetMultiple() returned an error; error=%v", err)
+	}
+	for i, p := range params {
+		if got[i].Value != p.Value {
+			t.Errorf("GetMultiple() returned an unexpected value for %v; want=%q, got=%q", p.Name, p.Value, got[i].Value)
+		}
+	}
+
+	// catch ciphertext copied to another param.
+	copied, _ := s.Value("/myapp/db/password")
+	if _, err := s.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/myapp/config"), Value: aws.String(copied), Overwrite: aws.Bool(true)}); err != nil {
+		t.Fatalf("PutParameter() returned an error; error=%v", err)
+	}
+	if _, err := c.GetMultiple(ctx, "/myapp/config"); !errors.As(err, &ErrDecryptionFailed{}) {
+		t.Errorf("GetMultiple() returned an unexpected error; want=ErrDecryptionFailed, got=%v", err)
+	}
+
+	// catch values replaced with plaintext, if encryption is required.
+	if _, err := s.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/myapp/config"), Value: aws.String("plaintext"), Overwrite: aws.Bool(true)}); err != nil {
+		t.Fatalf("PutParameter() returned an error; error=%v", err)
+	}
+	if _, err := c.GetMultiple(ctx, "/myapp/config"); err != nil {
+		t.Errorf("GetMultiple() returned an error; error=%v", err)
+	}
+	c.requireEncryption = true
+	if _, err := c.GetMultiple(ctx, "/myapp/config"); !errors.As(err, &ErrNotEncrypted{}) {
+		t.Errorf("GetMultiple() returned an unexpected error; want=ErrNotEncrypted, got=%v", err)
+	}
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/config", Value: "replaced", Type: ParameterTypeString, Overwrite: true}}); err != nil {
+		t.Errorf("Put() returned an error replacing a plaintext value; error=%v", err)
+	}
+	if _, err := c.GetByPath(ctx, "/myapp/db", true); err != nil {
+		t.Errorf("GetByPath() returned an error; error=%v", err)
+	}
+	c.requireEncryption = false
+
+	// catch values encrypted with a missing key, or without an encrypter.
+	c.encrypter, err = NewAESGCMEncrypter("k1", map[string][]byte{"k1": keys["k1"]})
+	if err != nil {
+		t.Fatalf("NewAESGCMEncrypter() returned an error; error=%v", err)
+	}
+	if _, err := c.GetMultiple(ctx, "/myapp/db/password"); !errors.As(err, &ErrUnknownKey{}) {
+		t.Errorf("GetMultiple() returned an unexpected error; want=ErrUnknownKey, got=%v", err)
+	}
+	c.encrypter = nil
+	if _, err := c.GetByPath(ctx, "/myapp", true); !errors.As(err, &ErrEncrypterNotConfigured{}) {
+		t.Errorf("GetByPath() returned an unexpected error; want=ErrEncrypterNotConfigured, got=%v", err)
+	}
+}
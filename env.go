@@ -0,0 +1,125 @@
+package paramstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// EnvCase is the case used for environment variable keys.
+type EnvCase string
+
+const (
+	EnvCaseUpper    EnvCase = "upper"    // Keys are upper-cased; the default.
+	EnvCaseLower    EnvCase = "lower"    // Keys are lower-cased.
+	EnvCasePreserve EnvCase = "preserve" // Keys keep the case used in the parameter name.
+)
+
+// EnvOptions configures how parameter names are converted into environment
+// variable keys.
+type EnvOptions struct {
+	Case      EnvCase // The case used for keys; defaults to EnvCaseUpper.
+	Separator string  // Replaces every character that isn't a letter or number; defaults to "_".
+	KeepPath  bool    // If true, the path a parameter was loaded from is kept in its key.
+}
+
+// Key converts a parameter name into an environment variable key, by removing
+// any leading "/" and replacing every character that isn't a letter or number
+// with the separator.
+func (opts EnvOptions) Key(name string) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "_"
+	}
+	var b strings.Builder
+	for _, r := range strings.TrimLeft(name, "/") {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteString(sep)
+		}
+	}
+	switch opts.Case {
+	case EnvCaseLower:
+		return strings.ToLower(b.String())
+	case EnvCasePreserve:
+		return b.String()
+	}
+	return strings.ToUpper(b.String())
+}
+
+// EnvKey converts a parameter name into an environment variable key, using the
+// default EnvOptions. For example, "/myapp/db-host" becomes "MYAPP_DB_HOST".
+func EnvKey(name string) string {
+	return EnvOptions{}.Key(name)
+}
+
+// Env retrieves every param under the given paths from paramstore, and
+// converts them into environment variables. Paths are given from least to most
+// specific, so a key found under a later path overrides the same key found
+// under an earlier path. Two params under the same path that convert into the
+// same key are returned as an error.
+func (c *Client) Env(ctx context.Context, paths []string, opts EnvOptions) (map[string]string, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Env")
+	defer span.End()
+
+	out := make(map[string]string)
+	for _, path := range paths {
+
+		// retrieve params.
+		params, err := c.GetByPath(newCtx, path, true)
+		if err != nil {
+			return nil, err
+		}
+
+		// convert params.
+		seen := make(map[string]string, len(params))
+		for _, p := range params {
+			name := p.Name
+			if !opts.KeepPath {
+				name = strings.TrimPrefix(name, strings.TrimRight(path, "/"))
+			}
+			key := opts.Key(name)
+			if other, ok := seen[key]; ok {
+				return nil, ErrEnvKeyCollision{key, other, p.Name}
+			}
+			seen[key] = p.Name
+			out[key] = p.Value
+		}
+	}
+	return out, nil
+}
+
+// MergeEnv merges the given variables into an environment, given in the form
+// returned by os.Environ(). If override is true, the given variables replace
+// any existing variables with the same key; otherwise existing variables are
+// kept. The merged environment is returned in the same form, sorted by key.
+func MergeEnv(environ []string, vars map[string]string, override bool) []string {
+	merged := environToMap(environ)
+	for k, v := range vars {
+		if _, exists := merged[k]; exists && !override {
+			continue
+		}
+		merged[k] = v
+	}
+	out := make([]string, 0, len(merged))
+	for k, v := range merged {
+		out = append(out, k+"="+v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// environToMap converts an environment, given in the form returned by
+// os.Environ(), into a map.
+func environToMap(environ []string) map[string]string {
+	out := make(map[string]string, len(environ))
+	for _, e := range environ {
+		k, v, _ := strings.Cut(e, "=")
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,15 @@
+package paramstore
+
+import "fmt"
+
+// ErrEnvKeyCollision is returned when two parameters convert into the same
+// environment variable key.
+type ErrEnvKeyCollision struct {
+	key    string
+	first  string
+	second string
+}
+
+func (e ErrEnvKeyCollision) Error() string {
+	return fmt.Sprintf("%q and %q both convert into the environment variable %q", e.first, e.second, e.key)
+}
@@ -0,0 +1,128 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_EnvKey(t *testing.T) {
+	tests := map[string]string{
+		"/myapp/prod/db-host": "MYAPP_PROD_DB_HOST",
+		"db.port":             "DB_PORT",
+		"/Already_Upper":      "ALREADY_UPPER",
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EnvKey(name); got != want {
+				t.Errorf("EnvKey() returned unexpected key; want=%v, got=%v", want, got)
+			}
+		})
+	}
+}
+
+func Test_EnvOptionsKey(t *testing.T) {
+	tests := map[string]struct {
+		opts EnvOptions
+		name string
+		want string
+	}{
+		"lower case": {
+			opts: EnvOptions{Case: EnvCaseLower},
+			name: "/DB/Host",
+			want: "db_host",
+		},
+		"preserve case": {
+			opts: EnvOptions{Case: EnvCasePreserve},
+			name: "/DB/host",
+			want: "DB_host",
+		},
+		"custom separator": {
+			opts: EnvOptions{Separator: "__"},
+			name: "/db/host-name",
+			want: "DB__HOST__NAME",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.opts.Key(tt.name); got != tt.want {
+				t.Errorf("Key() returned unexpected key; want=%v, got=%v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_Env(t *testing.T) {
+	store := Parameters{
+		{Name: "/myapp/default/db/host", Value: "localhost", Type: ParameterTypeString},
+		{Name: "/myapp/default/db/port", Value: "5432", Type: ParameterTypeString},
+		{Name: "/myapp/prod/db/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/clash/db-host", Value: "a", Type: ParameterTypeString},
+		{Name: "/myapp/clash/db/host", Value: "b", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		paths []string
+		opts  EnvOptions
+		want  map[string]string
+		err   string
+	}{
+		"load environment from paths": {
+			paths: []string{"/myapp/default", "/myapp/prod"},
+			want:  map[string]string{"DB_HOST": "db.prod", "DB_PORT": "5432"},
+		},
+		"load environment keeping path": {
+			paths: []string{"/myapp/prod"},
+			opts:  EnvOptions{KeepPath: true},
+			want:  map[string]string{"MYAPP_PROD_DB_HOST": "db.prod"},
+		},
+		"catch key collision": {
+			paths: []string{"/myapp/clash"},
+			err:   `"/myapp/clash/db-host" and "/myapp/clash/db/host" both convert into the environment variable "DB_HOST"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			got, err := c.Env(context.Background(), tt.paths, tt.opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Env() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Env() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Env() returned unexpected environment;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MergeEnv(t *testing.T) {
+	environ := []string{"HOME=/root", "DB_HOST=localhost"}
+	vars := map[string]string{"DB_HOST": "db.prod", "DB_PORT": "5432"}
+	tests := map[string]struct {
+		override bool
+		want     []string
+	}{
+		"merge environment": {
+			want: []string{"DB_HOST=localhost", "DB_PORT=5432", "HOME=/root"},
+		},
+		"override environment": {
+			override: true,
+			want:     []string{"DB_HOST=db.prod", "DB_PORT=5432", "HOME=/root"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := MergeEnv(environ, vars, tt.override); !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("MergeEnv() returned unexpected environment;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
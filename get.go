@@ -2,20 +2,22 @@ package paramstore
 
 import (
 	"context"
-	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	multierror "github.com/hashicorp/go-multierror"
-	"go.opentelemetry.io/otel"
 )
 
 // Get retrieves a single param from paramstore.
 func (c *Client) Get(ctx context.Context, name string) (out *Parameter, err error) {
 
 	// setup tracing.
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "Get")
-	defer span.End()
+	newCtx, span := c.startSpan(ctx, "Get",
+		attrCount.Int(1),
+		attrDecryption.Bool(c.withDecryption),
+	)
+	defer func() { endSpan(span, err) }()
 
 	// retrieve parameter.
 	param, err := c.GetMultiple(newCtx, name)
@@ -29,11 +31,26 @@ func (c *Client) Get(ctx context.Context, name string) (out *Parameter, err erro
 func (c *Client) GetMultiple(ctx context.Context, names ...string) (out Parameters, errs error) {
 
 	// setup tracing.
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "GetMultiple")
-	defer span.End()
+	newCtx, span := c.startSpan(ctx, "GetMultiple",
+		attrCount.Int(len(names)),
+		attrDecryption.Bool(c.withDecryption),
+	)
+	defer func() { endSpan(span, errs) }()
+
+	// qualify names.
+	names, given, errs := c.qualifyAll(names)
+	if errs != nil {
+		return nil, errs
+	}
+
+	// validate names, before making any calls.
+	if err := validateGetNames(names); err != nil {
+		return nil, err
+	}
 
 	// retrieve params in batches.
 	var invalid []string
+	var qualified []string // The qualified name of each param in out.
 	for i := 0; i < len(names); i += c.batchSize {
 
 		// determine rolling batch size.
@@ -47,39 +64,64 @@ func (c *Client) GetMultiple(ctx context.Context, names ...string) (out Paramete
 			Names:          names[i:size],
 			WithDecryption: &c.withDecryption,
 		}
-		resp, err := c.ssmsvc.GetParameters(newCtx, in)
+		batchCtx, batchSpan := c.startSpan(newCtx, "GetParameters",
+			attrCount.Int(len(in.Names)),
+			attrBatchIndex.Int(i/c.batchSize),
+			attrDecryption.Bool(c.withDecryption),
+		)
+		resp, err := c.ssmsvc.GetParameters(batchCtx, in)
 		if err != nil {
 			c.logger.Error("failed to get parameters",
 				"error", err,
 				"names", in.Names,
 				"decryption", *in.WithDecryption,
 			)
+			batchSpan.SetAttributes(attrRetryCount.Int(errRetryCount(err)))
+			endSpan(batchSpan, err)
 			errs = multierror.Append(errs, err)
 			continue
 		}
+		batchSpan.SetAttributes(
+			attrInvalidCount.Int(len(resp.InvalidParameters)),
+			attrRetryCount.Int(retryCount(resp.ResultMetadata)),
+		)
+		batchSpan.End()
 
 		// parse params from response.
 		for _, p := range resp.Parameters {
-
-			// there's a very slim change the Value is missing.
-			if p.Value == nil {
-				p.Value = aws.String("")
-			}
-
-			out = append(out, Parameter{
-				Name:  *p.Name,
-				Value: *p.Value,
-				Type:  ParameterType(p.Type),
-			})
+			// NOTE: names are returned without any selector given, so the
+			// selector is added back to find the name given.
+			s := selector(aws.ToString(p.Selector))
+			name := strings.TrimSuffix(c.unqualifyFrom(given, *p.Name+s), s)
+			out = append(out, newParameter(name, p))
+			qualified = append(qualified, *p.Name)
 		}
 		invalid = append(invalid, resp.InvalidParameters...)
 	}
 
+	// reassemble values split into chunks, if needed.
+	if c.largeValues {
+		if err := c.assemble(newCtx, out, qualified, nil); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	// decrypt values, if needed.
+	if err := c.decryptAll(newCtx, out, qualified); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	// decode values.
+	if err := c.decodeAll(out); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	// return params + errs.
+	span.SetAttributes(attrInvalidCount.Int(len(invalid)))
 	if len(invalid) > 0 {
 		for _, i := range invalid {
 			c.logger.Warn("found invalid parameters", "param", i)
-			errs = multierror.Append(errs, fmt.Errorf("%q is an invalid parameter", i))
+			errs = multierror.Append(errs, ErrInvalidParameter{c.unqualifyFrom(given, i)})
 		}
 	}
 	return out, errs
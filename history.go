@@ -0,0 +1,118 @@
+package paramstore
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// ParameterVersion is a single version of a Parameter, as returned in the
+// history of a parameter.
+type ParameterVersion struct {
+	Parameter
+	Labels []string // The labels attached to this version.
+}
+
+// ParameterHistory is a slice of ParameterVersion, from oldest to newest.
+type ParameterHistory []ParameterVersion
+
+// History retrieves every version of a single param from paramstore.
+func (c *Client) History(ctx context.Context, name string) (out ParameterHistory, err error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "History")
+	defer span.End()
+
+	// qualify name.
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve history, one page at a time.
+	in := &ssm.GetParameterHistoryInput{
+		Name:           aws.String(qualified),
+		WithDecryption: &c.withDecryption,
+	}
+	paginator := ssm.NewGetParameterHistoryPaginator(c.ssmsvc, in)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(newCtx)
+		if err != nil {
+			c.logger.Error("failed to get parameter history",
+				"error", err,
+				"name", qualified,
+				"decryption", c.withDecryption,
+			)
+			return nil, err
+		}
+		for _, h := range resp.Parameters {
+			v := ParameterVersion{
+				Parameter: Parameter{
+					Name:    name,
+					Value:   aws.ToString(h.Value),
+					Type:    ParameterType(h.Type),
+					Tier:    ParameterTier(h.Tier),
+					Version: h.Version,
+
+					Description: aws.ToString(h.Description),
+					KeyId:       aws.ToString(h.KeyId),
+				},
+				Labels: h.Labels,
+			}
+			if h.LastModifiedDate != nil {
+				v.LastModifiedDate = *h.LastModifiedDate
+			}
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// Label attaches one or more labels to a version of a single param in
+// paramstore. If version is 0, the labels are attached to the latest version.
+func (c *Client) Label(ctx context.Context, name string, version int64, labels ...string) error {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Label")
+	defer span.End()
+
+	// qualify name.
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return err
+	}
+
+	// skip labelling, in dry-run mode.
+	if c.isDryRun(newCtx) {
+		c.logger.Info("dry run: would label parameter",
+			"name", qualified,
+			"version", version,
+			"labels", labels,
+		)
+		return nil
+	}
+
+	// label parameter.
+	in := &ssm.LabelParameterVersionInput{
+		Name:   aws.String(qualified),
+		Labels: labels,
+	}
+	if version > 0 {
+		in.ParameterVersion = aws.Int64(version)
+	}
+	resp, err := c.ssmsvc.LabelParameterVersion(newCtx, in)
+	if err != nil {
+		c.logger.Error("failed to label parameter",
+			"error", err,
+			"name", qualified,
+			"version", version,
+			"labels", labels,
+		)
+		return err
+	}
+	if len(resp.InvalidLabels) > 0 {
+		return ErrInvalidLabels{name, resp.InvalidLabels}
+	}
+	return nil
+}
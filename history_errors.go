@@ -0,0 +1,17 @@
+package paramstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidLabels is returned when AWS SSM Parameter Store rejects one or more
+// labels given to Label().
+type ErrInvalidLabels struct {
+	name   string
+	labels []string
+}
+
+func (e ErrInvalidLabels) Error() string {
+	return fmt.Sprintf("invalid labels for %q: %v", e.name, strings.Join(e.labels, ", "))
+}
@@ -0,0 +1,77 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_History(t *testing.T) {
+	mock, _ := newMockSSMStore(Parameters{{Name: "/name", Value: "one", Type: ParameterTypeString}})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	ctx := context.Background()
+	if err := c.Put(ctx, Parameters{{Name: "/name", Value: "two", Overwrite: true}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if err := c.Label(ctx, "/name", 1, "stable"); err != nil {
+		t.Fatalf("Label() returned an error; error=%v", err)
+	}
+	got, err := c.History(ctx, "/name")
+	if err != nil {
+		t.Fatalf("History() returned an error; error=%v", err)
+	}
+	var values []string
+	for _, v := range got {
+		values = append(values, v.Value)
+	}
+	if want := []string{"one", "two"}; !reflect.DeepEqual(want, values) {
+		t.Errorf("History() returned unexpected values; want=%v, got=%v", want, values)
+	}
+	if want := []string{"stable"}; !reflect.DeepEqual(want, got[0].Labels) {
+		t.Errorf("History() returned unexpected labels; want=%v, got=%v", want, got[0].Labels)
+	}
+	if got[1].Version != 2 {
+		t.Errorf("History() returned unexpected version; want=%v, got=%v", 2, got[1].Version)
+	}
+}
+
+func Test_Label(t *testing.T) {
+	tests := map[string]struct {
+		name   string
+		labels []string
+		err    string
+	}{
+		"label parameter": {
+			name:   "/name",
+			labels: []string{"stable"},
+		},
+		"catch invalid labels": {
+			name:   "/name",
+			labels: []string{"stable", "aws-thing"},
+			err:    `invalid labels for "/name": aws-thing`,
+		},
+		"catch missing parameter": {
+			name:   "/missing",
+			labels: []string{"stable"},
+			err:    "ParameterNotFound",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(Parameters{{Name: "/name", Value: "one", Type: ParameterTypeString}})
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			err := c.Label(context.Background(), tt.name, 0, tt.labels...)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Label() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Label() returned an error; error=%v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package mockssm lets the commands in this module run against the in-memory
+// mock of AWS SSM Parameter Store used by paramstore's own tests, instead of
+// AWS, without exporting the mock from paramstore.
+package mockssm
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the key a Store is kept under in a context.
+type contextKey struct{}
+
+// Store holds the mock shared by every client created with a context from
+// NewContext. The mock itself is created by paramstore, the first time a
+// client is created.
+type Store struct {
+	mu     sync.Mutex
+	client any
+}
+
+// NewContext returns a copy of the given context, holding the given store.
+func NewContext(ctx context.Context, s *Store) context.Context {
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// FromContext returns the store held by the given context, if any.
+func FromContext(ctx context.Context) (*Store, bool) {
+	s, ok := ctx.Value(contextKey{}).(*Store)
+	return s, ok
+}
+
+// Client returns the mock held by the store, creating it with the given func
+// if needed.
+func (s *Store) Client(create func() any) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		s.client = create()
+	}
+	return s.client
+}
@@ -3,8 +3,17 @@ package paramstore
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // mockSSMClient is a mock implementation of the ssm.Client.
@@ -14,9 +23,16 @@ type mockSSMClient struct {
 	ssm.Client
 
 	// funcs.
-	GetParametersFunc    func(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error)
-	PutParameterFunc     func(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
-	DeleteParametersFunc func(ctx context.Context, params *ssm.DeleteParametersInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	GetParametersFunc          func(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error)
+	PutParameterFunc           func(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	DeleteParametersFunc       func(ctx context.Context, params *ssm.DeleteParametersInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	GetParametersByPathFunc    func(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+	GetParameterHistoryFunc    func(ctx context.Context, params *ssm.GetParameterHistoryInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterHistoryOutput, error)
+	LabelParameterVersionFunc  func(ctx context.Context, params *ssm.LabelParameterVersionInput, optFns ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error)
+	AddTagsToResourceFunc      func(ctx context.Context, params *ssm.AddTagsToResourceInput, optFns ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error)
+	ListTagsForResourceFunc    func(ctx context.Context, params *ssm.ListTagsForResourceInput, optFns ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error)
+	RemoveTagsFromResourceFunc func(ctx context.Context, params *ssm.RemoveTagsFromResourceInput, optFns ...func(*ssm.Options)) (*ssm.RemoveTagsFromResourceOutput, error)
+	DescribeParametersFunc     func(ctx context.Context, params *ssm.DescribeParametersInput, optFns ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error)
 }
 
 // GetParameters mocks the GetParameters function.
@@ -42,3 +58,439 @@ func (m *mockSSMClient) DeleteParameters(ctx context.Context, params *ssm.Delete
 	}
 	return nil, errors.New("DeleteParametersFunc is not implemented")
 }
+
+// GetParametersByPath mocks the GetParametersByPath function.
+func (m *mockSSMClient) GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	if m.GetParametersByPathFunc != nil {
+		return m.GetParametersByPathFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("GetParametersByPathFunc is not implemented")
+}
+
+// GetParameterHistory mocks the GetParameterHistory function.
+func (m *mockSSMClient) GetParameterHistory(ctx context.Context, params *ssm.GetParameterHistoryInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterHistoryOutput, error) {
+	if m.GetParameterHistoryFunc != nil {
+		return m.GetParameterHistoryFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("GetParameterHistoryFunc is not implemented")
+}
+
+// LabelParameterVersion mocks the LabelParameterVersion function.
+func (m *mockSSMClient) LabelParameterVersion(ctx context.Context, params *ssm.LabelParameterVersionInput, optFns ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error) {
+	if m.LabelParameterVersionFunc != nil {
+		return m.LabelParameterVersionFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("LabelParameterVersionFunc is not implemented")
+}
+
+// AddTagsToResource mocks the AddTagsToResource function.
+func (m *mockSSMClient) AddTagsToResource(ctx context.Context, params *ssm.AddTagsToResourceInput, optFns ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+	if m.AddTagsToResourceFunc != nil {
+		return m.AddTagsToResourceFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("AddTagsToResourceFunc is not implemented")
+}
+
+// ListTagsForResource mocks the ListTagsForResource function.
+func (m *mockSSMClient) ListTagsForResource(ctx context.Context, params *ssm.ListTagsForResourceInput, optFns ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error) {
+	if m.ListTagsForResourceFunc != nil {
+		return m.ListTagsForResourceFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("ListTagsForResourceFunc is not implemented")
+}
+
+// RemoveTagsFromResource mocks the RemoveTagsFromResource function.
+func (m *mockSSMClient) RemoveTagsFromResource(ctx context.Context, params *ssm.RemoveTagsFromResourceInput, optFns ...func(*ssm.Options)) (*ssm.RemoveTagsFromResourceOutput, error) {
+	if m.RemoveTagsFromResourceFunc != nil {
+		return m.RemoveTagsFromResourceFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("RemoveTagsFromResourceFunc is not implemented")
+}
+
+// DescribeParameters mocks the DescribeParameters function.
+func (m *mockSSMClient) DescribeParameters(ctx context.Context, params *ssm.DescribeParametersInput, optFns ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	if m.DescribeParametersFunc != nil {
+		return m.DescribeParametersFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("DescribeParametersFunc is not implemented")
+}
+
+// mockSTSClient is a mock implementation of the sts.Client.
+type mockSTSClient struct {
+	GetCallerIdentityFunc func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// GetCallerIdentity mocks the GetCallerIdentity function.
+func (m *mockSTSClient) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	if m.GetCallerIdentityFunc != nil {
+		return m.GetCallerIdentityFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("GetCallerIdentityFunc is not implemented")
+}
+
+// newMockAccount returns a mockSTSClient for the given AWS account.
+func newMockAccount(account string) *mockSTSClient {
+	return &mockSTSClient{
+		GetCallerIdentityFunc: func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+			return &sts.GetCallerIdentityOutput{Account: aws.String(account)}, nil
+		},
+	}
+}
+
+// mockSSMStore is an in-memory store, used to back a mockSSMClient so that it
+// mimics the behavior of AWS SSM Parameter Store across multiple calls.
+type mockSSMStore struct {
+	mu         sync.Mutex
+	parameters map[string][]types.ParameterHistory // The versions of each parameter, oldest first.
+	tags       map[string]map[string]string        // The tags on each parameter.
+}
+
+// newMockSSMStore returns a mockSSMClient backed by a mockSSMStore, which is
+// populated with the given parameters.
+func newMockSSMStore(parameters Parameters) (*mockSSMClient, *mockSSMStore) {
+	s := &mockSSMStore{
+		parameters: make(map[string][]types.ParameterHistory),
+		tags:       make(map[string]map[string]string),
+	}
+	for _, p := range parameters {
+		var description, keyId *string
+		if p.Description != "" {
+			description = aws.String(p.Description)
+		}
+		if p.KeyId != "" {
+			keyId = aws.String(p.KeyId)
+		}
+		s.put(p.Name, p.Value, types.ParameterType(p.Type), types.ParameterTier(p.Tier), description, keyId)
+	}
+	m := &mockSSMClient{
+		GetParametersFunc:          s.GetParameters,
+		PutParameterFunc:           s.PutParameter,
+		DeleteParametersFunc:       s.DeleteParameters,
+		GetParametersByPathFunc:    s.GetParametersByPath,
+		GetParameterHistoryFunc:    s.GetParameterHistory,
+		LabelParameterVersionFunc:  s.LabelParameterVersion,
+		AddTagsToResourceFunc:      s.AddTagsToResource,
+		ListTagsForResourceFunc:    s.ListTagsForResource,
+		RemoveTagsFromResourceFunc: s.RemoveTagsFromResource,
+		DescribeParametersFunc:     s.DescribeParameters,
+	}
+	return m, s
+}
+
+// put adds a new version of a parameter to the store.
+func (s *mockSSMStore) put(
+	name, value string,
+	t types.ParameterType,
+	tier types.ParameterTier,
+	description, keyId *string,
+) int64 {
+	if tier == "" {
+		tier = types.ParameterTierStandard
+	}
+	version := int64(len(s.parameters[name]) + 1)
+	now := time.Now()
+	s.parameters[name] = append(s.parameters[name], types.ParameterHistory{
+		Name:             aws.String(name),
+		Value:            aws.String(value),
+		Type:             t,
+		Tier:             tier,
+		Description:      description,
+		KeyId:            keyId,
+		Version:          version,
+		LastModifiedDate: &now,
+	})
+	return version
+}
+
+// latest returns the latest version of a parameter in the store.
+func (s *mockSSMStore) latest(name string) (types.ParameterHistory, bool) {
+	versions, ok := s.parameters[name]
+	if !ok {
+		return types.ParameterHistory{}, false
+	}
+	return versions[len(versions)-1], true
+}
+
+// toParameter converts a version of a parameter into a ssm/types.Parameter.
+func (s *mockSSMStore) toParameter(h types.ParameterHistory) types.Parameter {
+	return types.Parameter{
+		Name:             h.Name,
+		Value:            h.Value,
+		Type:             h.Type,
+		Version:          h.Version,
+		LastModifiedDate: h.LastModifiedDate,
+	}
+}
+
+// Value returns the latest value of a parameter in the store.
+func (s *mockSSMStore) Value(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.latest(name)
+	return aws.ToString(h.Value), ok
+}
+
+// Names returns the names of every parameter in the store, sorted.
+func (s *mockSSMStore) Names() (out []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for n := range s.parameters {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// GetParameters mimics the GetParameters function, including version and
+// label selectors.
+func (s *mockSSMStore) GetParameters(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := &ssm.GetParametersOutput{}
+	for _, n := range params.Names {
+		name, selector, hasSelector := strings.Cut(n, ":")
+		versions, ok := s.parameters[name]
+		if !ok {
+			out.InvalidParameters = append(out.InvalidParameters, n)
+			continue
+		}
+		h := versions[len(versions)-1]
+		if hasSelector {
+			found := false
+			for _, v := range versions {
+				if strconv.FormatInt(v.Version, 10) == selector || slices.Contains(v.Labels, selector) {
+					h, found = v, true
+				}
+			}
+			if !found {
+				out.InvalidParameters = append(out.InvalidParameters, n)
+				continue
+			}
+		}
+		p := s.toParameter(h)
+		if hasSelector {
+			p.Selector = aws.String(":" + selector)
+		}
+		out.Parameters = append(out.Parameters, p)
+	}
+	return out, nil
+}
+
+// PutParameter mimics the PutParameter function.
+func (s *mockSSMStore) PutParameter(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := aws.ToString(params.Name)
+	current, exists := s.latest(name)
+	if exists && !aws.ToBool(params.Overwrite) {
+		return nil, &types.ParameterAlreadyExists{Message: aws.String("The parameter already exists.")}
+	}
+	t, tier := params.Type, params.Tier
+	if exists && t == "" {
+		t = current.Type
+	}
+	if exists && tier == "" {
+		tier = current.Tier
+	}
+	version := s.put(name, aws.ToString(params.Value), t, tier, params.Description, params.KeyId)
+	if !exists && len(params.Tags) > 0 {
+		s.tags[name] = make(map[string]string)
+		for _, tag := range params.Tags {
+			s.tags[name][aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return &ssm.PutParameterOutput{Version: version, Tier: tier}, nil
+}
+
+// DeleteParameters mimics the DeleteParameters function.
+func (s *mockSSMStore) DeleteParameters(ctx context.Context, params *ssm.DeleteParametersInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := &ssm.DeleteParametersOutput{}
+	for _, n := range params.Names {
+		if _, ok := s.parameters[n]; !ok {
+			out.InvalidParameters = append(out.InvalidParameters, n)
+			continue
+		}
+		delete(s.parameters, n)
+		delete(s.tags, n)
+		out.DeletedParameters = append(out.DeletedParameters, n)
+	}
+	return out, nil
+}
+
+// GetParametersByPath mimics the GetParametersByPath function, including
+// pagination.
+func (s *mockSSMStore) GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path := strings.TrimRight(aws.ToString(params.Path), "/") + "/"
+	var names []string
+	for n := range s.parameters {
+		rest, ok := strings.CutPrefix(n, path)
+		if !ok || (!aws.ToBool(params.Recursive) && strings.Contains(rest, "/")) {
+			continue
+		}
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	// paginate.
+	start := 0
+	if params.NextToken != nil {
+		start, _ = strconv.Atoi(*params.NextToken)
+	}
+	end := len(names)
+	if params.MaxResults != nil && start+int(*params.MaxResults) < end {
+		end = start + int(*params.MaxResults)
+	}
+	out := &ssm.GetParametersByPathOutput{}
+	for _, n := range names[start:end] {
+		h, _ := s.latest(n)
+		out.Parameters = append(out.Parameters, s.toParameter(h))
+	}
+	if end < len(names) {
+		out.NextToken = aws.String(strconv.Itoa(end))
+	}
+	return out, nil
+}
+
+// GetParameterHistory mimics the GetParameterHistory function.
+func (s *mockSSMStore) GetParameterHistory(ctx context.Context, params *ssm.GetParameterHistoryInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterHistoryOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	versions, ok := s.parameters[aws.ToString(params.Name)]
+	if !ok {
+		return nil, &types.ParameterNotFound{Message: aws.String("The parameter couldn't be found.")}
+	}
+	return &ssm.GetParameterHistoryOutput{
+		Parameters: append([]types.ParameterHistory{}, versions...),
+	}, nil
+}
+
+// LabelParameterVersion mimics the LabelParameterVersion function.
+func (s *mockSSMStore) LabelParameterVersion(ctx context.Context, params *ssm.LabelParameterVersionInput, optFns ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	versions, ok := s.parameters[aws.ToString(params.Name)]
+	if !ok {
+		return nil, &types.ParameterNotFound{Message: aws.String("The parameter couldn't be found.")}
+	}
+	version := int64(len(versions))
+	if params.ParameterVersion != nil {
+		version = *params.ParameterVersion
+	}
+	out := &ssm.LabelParameterVersionOutput{ParameterVersion: version}
+	for _, l := range params.Labels {
+		if strings.HasPrefix(l, "aws") || strings.HasPrefix(l, "ssm") {
+			out.InvalidLabels = append(out.InvalidLabels, l)
+			continue
+		}
+		for i := range versions {
+			versions[i].Labels = slices.DeleteFunc(versions[i].Labels, func(v string) bool { return v == l })
+			if versions[i].Version == version {
+				versions[i].Labels = append(versions[i].Labels, l)
+			}
+		}
+	}
+	return out, nil
+}
+
+// AddTagsToResource mimics the AddTagsToResource function.
+func (s *mockSSMStore) AddTagsToResource(ctx context.Context, params *ssm.AddTagsToResourceInput, optFns ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := aws.ToString(params.ResourceId)
+	if _, ok := s.parameters[name]; !ok {
+		return nil, &types.InvalidResourceId{Message: aws.String("The resource ID isn't valid.")}
+	}
+	if s.tags[name] == nil {
+		s.tags[name] = make(map[string]string)
+	}
+	for _, t := range params.Tags {
+		s.tags[name][aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return &ssm.AddTagsToResourceOutput{}, nil
+}
+
+// ListTagsForResource mimics the ListTagsForResource function.
+func (s *mockSSMStore) ListTagsForResource(ctx context.Context, params *ssm.ListTagsForResourceInput, optFns ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := aws.ToString(params.ResourceId)
+	if _, ok := s.parameters[name]; !ok {
+		return nil, &types.InvalidResourceId{Message: aws.String("The resource ID isn't valid.")}
+	}
+	out := &ssm.ListTagsForResourceOutput{}
+	for k, v := range s.tags[name] {
+		out.TagList = append(out.TagList, types.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	return out, nil
+}
+
+// RemoveTagsFromResource mimics the RemoveTagsFromResource function.
+func (s *mockSSMStore) RemoveTagsFromResource(ctx context.Context, params *ssm.RemoveTagsFromResourceInput, optFns ...func(*ssm.Options)) (*ssm.RemoveTagsFromResourceOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := aws.ToString(params.ResourceId)
+	if _, ok := s.parameters[name]; !ok {
+		return nil, &types.InvalidResourceId{Message: aws.String("The resource ID isn't valid.")}
+	}
+	for _, k := range params.TagKeys {
+		delete(s.tags[name], k)
+	}
+	return &ssm.RemoveTagsFromResourceOutput{}, nil
+}
+
+// DescribeParameters mimics the DescribeParameters function, including the
+// Path and Name filters, and pagination.
+func (s *mockSSMStore) DescribeParameters(ctx context.Context, params *ssm.DescribeParametersInput, optFns ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path, recursive, equals := "", true, []string(nil)
+	for _, f := range params.ParameterFilters {
+		switch aws.ToString(f.Key) {
+		case "Path":
+			path = strings.TrimRight(f.Values[0], "/") + "/"
+			recursive = aws.ToString(f.Option) == "Recursive"
+		case "Name":
+			equals = f.Values
+		}
+	}
+	var names []string
+	for n := range s.parameters {
+		rest, ok := strings.CutPrefix(n, path)
+		if !ok || (!recursive && strings.Contains(rest, "/")) || (equals != nil && !slices.Contains(equals, n)) {
+			continue
+		}
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	// paginate.
+	start := 0
+	if params.NextToken != nil {
+		start, _ = strconv.Atoi(*params.NextToken)
+	}
+	end := len(names)
+	if params.MaxResults != nil && start+int(*params.MaxResults) < end {
+		end = start + int(*params.MaxResults)
+	}
+	out := &ssm.DescribeParametersOutput{}
+	for _, n := range names[start:end] {
+		h, _ := s.latest(n)
+		out.Parameters = append(out.Parameters, types.ParameterMetadata{
+			Name:             h.Name,
+			Type:             h.Type,
+			Tier:             h.Tier,
+			Description:      h.Description,
+			KeyId:            h.KeyId,
+			Version:          h.Version,
+			LastModifiedDate: h.LastModifiedDate,
+		})
+	}
+	if end < len(names) {
+		out.NextToken = aws.String(strconv.Itoa(end))
+	}
+	return out, nil
+}
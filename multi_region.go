@@ -0,0 +1,332 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// MultiRegionClient wraps multiple Clients, each configured for a different
+// AWS region. Writes are replicated to every region, while reads are served
+// from the primary region and fail over to the secondary regions (in order)
+// when the primary returns an error or times out.
+type MultiRegionClient struct {
+
+	// clients.
+	primary     *Client   // The client used for reads, when it's healthy.
+	secondaries []*Client // The clients used for reads, when the primary isn't.
+
+	// misc.
+	readTimeout time.Duration // The time to wait for a region before failing over.
+}
+
+// MultiRegionOption configures a MultiRegionClient.
+type MultiRegionOption func(*MultiRegionClient) error
+
+// WithReadTimeout configures how long a read waits for a region to respond
+// before failing over to the next region. A zero timeout disables this.
+func WithReadTimeout(timeout time.Duration) MultiRegionOption {
+	return func(m *MultiRegionClient) error {
+		if timeout < 0 {
+			return errors.New("readTimeout must be greater than or equal to 0")
+		}
+		m.readTimeout = timeout
+		return nil
+	}
+}
+
+// NewMultiRegion creates and returns a new MultiRegionClient. The primary
+// client is used for reads, while the secondaries are used for reads only when
+// the primary (or the secondary before it) fails. Each client must be
+// configured for a different AWS region.
+func NewMultiRegion(
+	ctx context.Context,
+	primary *Client,
+	secondaries []*Client,
+	options ...MultiRegionOption,
+) (*MultiRegionClient, error) {
+
+	// check clients.
+	if primary == nil {
+		return nil, ErrMultiRegionMissingPrimary{}
+	}
+
+	// setup tracing.
+	_, span := primary.tracer().Start(ctx, "NewMultiRegion")
+	defer span.End()
+
+	regions := map[string]bool{primary.awsRegion: true}
+	for _, s := range secondaries {
+		if s == nil {
+			return nil, ErrMultiRegionMissingSecondary{}
+		}
+		if regions[s.awsRegion] {
+			return nil, ErrMultiRegionDuplicateRegion{s.awsRegion}
+		}
+		regions[s.awsRegion] = true
+	}
+
+	// setup client.
+	m := &MultiRegionClient{
+		primary:     primary,
+		secondaries: secondaries,
+	}
+
+	// overwrite client with any given options.
+	for _, o := range options {
+		if err := o(m); err != nil {
+			return nil, ErrClientFailedToSetOption{err}
+		}
+	}
+	return m, nil
+}
+
+// clients returns every client, starting with the primary.
+func (m *MultiRegionClient) clients() []*Client {
+	return append([]*Client{m.primary}, m.secondaries...)
+}
+
+// RegionResult is the result of a write to a single region.
+type RegionResult struct {
+	Region string // The region the write was made to.
+	Err    error  // The error returned by the region, if any.
+}
+
+// RegionResults is a slice of RegionResult.
+type RegionResults []RegionResult
+
+// Failed returns the results of the regions that returned an error.
+func (results RegionResults) Failed() (out RegionResults) {
+	for _, r := range results {
+		if r.Err != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// replicate runs the given write against every region concurrently, returning
+// the result for each region (in the same order as the clients) and any errors.
+func (m *MultiRegionClient) replicate(
+	ctx context.Context,
+	write func(context.Context, *Client) error,
+) (results RegionResults, errs error) {
+	clients := m.clients()
+	results = make(RegionResults, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			results[i] = RegionResult{
+				Region: c.awsRegion,
+				Err:    write(ctx, c),
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	// collate errors.
+	for _, r := range results.Failed() {
+		errs = multierror.Append(errs, ErrRegionFailed{r.Region, r.Err})
+	}
+	return results, errs
+}
+
+// Put uploads one or more params to paramstore in every region.
+func (m *MultiRegionClient) Put(
+	ctx context.Context,
+	parameters Parameters,
+) (results RegionResults, errs error) {
+
+	// setup tracing.
+	newCtx, span := m.primary.tracer().Start(ctx, "MultiRegionPut")
+	defer span.End()
+
+	return m.replicate(newCtx, func(ctx context.Context, c *Client) error {
+		return c.Put(ctx, parameters)
+	})
+}
+
+// Delete deletes one or more params from paramstore in every region.
+func (m *MultiRegionClient) Delete(
+	ctx context.Context,
+	names ...string,
+) (results RegionResults, errs error) {
+
+	// setup tracing.
+	newCtx, span := m.primary.tracer().Start(ctx, "MultiRegionDelete")
+	defer span.End()
+
+	return m.replicate(newCtx, func(ctx context.Context, c *Client) error {
+		return c.Delete(ctx, names...)
+	})
+}
+
+// Get retrieves a single param from paramstore, failing over to the secondary
+// regions if the primary region is unavailable.
+func (m *MultiRegionClient) Get(ctx context.Context, name string) (*Parameter, error) {
+
+	// setup tracing.
+	newCtx, span := m.primary.tracer().Start(ctx, "MultiRegionGet")
+	defer span.End()
+
+	// retrieve parameter.
+	params, err := m.GetMultiple(newCtx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &params[0], nil
+}
+
+// GetMultiple retrieves one or more params from paramstore, failing over to
+// the secondary regions if the primary region is unavailable. A region that
+// reports invalid parameters is considered available, so those errors are
+// returned as-is rather than triggering a failover.
+func (m *MultiRegionClient) GetMultiple(
+	ctx context.Context,
+	names ...string,
+) (out Parameters, errs error) {
+
+	// setup tracing.
+	newCtx, span := m.primary.tracer().Start(ctx, "MultiRegionGetMultiple")
+	defer span.End()
+
+	// retrieve params, one region at a time.
+	var failures error
+	for _, c := range m.clients() {
+		out, err := m.getMultiple(newCtx, c, names...)
+		if err == nil || onlyInvalidParameters(err) {
+			return out, err
+		}
+		c.logger.Warn("failed to get parameters from region, failing over",
+			"error", err,
+			"region", c.awsRegion,
+		)
+		failures = multierror.Append(failures, ErrRegionFailed{c.awsRegion, err})
+	}
+	return nil, failures
+}
+
+// getMultiple retrieves one or more params from a single region, respecting
+// the read timeout configured for this client.
+func (m *MultiRegionClient) getMultiple(
+	ctx context.Context,
+	c *Client,
+	names ...string,
+) (Parameters, error) {
+	if m.readTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.readTimeout)
+		defer cancel()
+	}
+	return c.GetMultiple(ctx, names...)
+}
+
+// DriftKind describes how a parameter differs between two regions, or two
+// locations given to Diff().
+type DriftKind string
+
+const (
+	DriftKindMissing          DriftKind = "missing"           // The parameter is missing in the secondary region (or right location).
+	DriftKindUnexpected       DriftKind = "unexpected"        // The parameter is missing in the primary region (or left location).
+	DriftKindValueMismatch    DriftKind = "value-mismatch"    // The parameter has a different value.
+	DriftKindTypeMismatch     DriftKind = "type-mismatch"     // The parameter has a different type.
+	DriftKindMetadataMismatch DriftKind = "metadata-mismatch" // The parameter has a different tier or description.
+)
+
+// RegionalDrift describes a parameter that differs between the primary region
+// and a secondary region.
+type RegionalDrift struct {
+	Name   string    // The name of the parameter.
+	Region string    // The secondary region that differs from the primary.
+	Kind   DriftKind // How the parameter differs.
+}
+
+// RegionalDrifts is a slice of RegionalDrift.
+type RegionalDrifts []RegionalDrift
+
+// Drift compares the given params in the primary region with each secondary
+// region, returning every difference found. Values are compared, but never
+// returned, so SecureString values aren't exposed by the result.
+func (m *MultiRegionClient) Drift(
+	ctx context.Context,
+	names ...string,
+) (out RegionalDrifts, errs error) {
+
+	// setup tracing.
+	newCtx, span := m.primary.tracer().Start(ctx, "MultiRegionDrift")
+	defer span.End()
+
+	// retrieve params from the primary region.
+	primary, err := m.snapshot(newCtx, m.primary, names...)
+	if err != nil {
+		return nil, ErrRegionFailed{m.primary.awsRegion, err}
+	}
+
+	// compare against each secondary region.
+	for _, c := range m.secondaries {
+		secondary, err := m.snapshot(newCtx, c, names...)
+		if err != nil {
+			errs = multierror.Append(errs, ErrRegionFailed{c.awsRegion, err})
+			continue
+		}
+		for _, n := range names {
+			p, inPrimary := primary[n]
+			s, inSecondary := secondary[n]
+			var kind DriftKind
+			switch {
+			case !inPrimary && !inSecondary:
+				continue
+			case !inSecondary:
+				kind = DriftKindMissing
+			case !inPrimary:
+				kind = DriftKindUnexpected
+			case p.Type != s.Type:
+				kind = DriftKindTypeMismatch
+			case p.Value != s.Value:
+				kind = DriftKindValueMismatch
+			default:
+				continue
+			}
+			out = append(out, RegionalDrift{Name: n, Region: c.awsRegion, Kind: kind})
+		}
+	}
+	return out, errs
+}
+
+// snapshot retrieves the given params from a single region as a map, keyed by
+// name. Params that are missing from the region are left out of the map.
+func (m *MultiRegionClient) snapshot(
+	ctx context.Context,
+	c *Client,
+	names ...string,
+) (map[string]Parameter, error) {
+	params, err := m.getMultiple(ctx, c, names...)
+	if err != nil && !onlyInvalidParameters(err) {
+		return nil, err
+	}
+	out := make(map[string]Parameter, len(params))
+	for _, p := range params {
+		out[p.Name] = p
+	}
+	return out, nil
+}
+
+// onlyInvalidParameters reports whether the given error is made up entirely of
+// ErrInvalidParameter errors.
+func onlyInvalidParameters(err error) bool {
+	var merr *multierror.Error
+	if !errors.As(err, &merr) {
+		return errors.As(err, &ErrInvalidParameter{})
+	}
+	for _, e := range merr.Errors {
+		if !errors.As(e, &ErrInvalidParameter{}) {
+			return false
+		}
+	}
+	return len(merr.Errors) > 0
+}
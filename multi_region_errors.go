@@ -0,0 +1,44 @@
+package paramstore
+
+import "fmt"
+
+// ErrMultiRegionMissingPrimary is returned when a MultiRegionClient is created
+// without a primary client.
+type ErrMultiRegionMissingPrimary struct{}
+
+func (e ErrMultiRegionMissingPrimary) Error() string {
+	return "a primary client is required"
+}
+
+// ErrMultiRegionMissingSecondary is returned when a MultiRegionClient is
+// created with a nil secondary client.
+type ErrMultiRegionMissingSecondary struct{}
+
+func (e ErrMultiRegionMissingSecondary) Error() string {
+	return "secondary clients cannot be nil"
+}
+
+// ErrMultiRegionDuplicateRegion is returned when a MultiRegionClient is created
+// with more than one client for the same region.
+type ErrMultiRegionDuplicateRegion struct {
+	region string
+}
+
+func (e ErrMultiRegionDuplicateRegion) Error() string {
+	return fmt.Sprintf("more than one client is configured for region %q", e.region)
+}
+
+// ErrRegionFailed is returned when an operation fails in a single region of a
+// MultiRegionClient.
+type ErrRegionFailed struct {
+	Region string
+	err    error
+}
+
+func (e ErrRegionFailed) Error() string {
+	return fmt.Sprintf("region %q failed: %v", e.Region, e.err)
+}
+
+func (e ErrRegionFailed) Unwrap() error {
+	return e.err
+}
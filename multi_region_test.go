@@ -0,0 +1,270 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// newTestRegionClient returns a Client for the given region, backed by the
+// given mock.
+func newTestRegionClient(region string, mock *mockSSMClient) *Client {
+	return &Client{
+		awsRegion: region,
+		logger:    slog.Default(),
+		batchSize: 10,
+		ssmsvc:    mock,
+	}
+}
+
+// mockGetParametersFrom is a mock used to mimic the behavior of pulling
+// multiple parameters from AWS SSM Parameter Store, where the store only
+// contains the given parameters.
+func mockGetParametersFrom(
+	parameters Parameters,
+) func(ctx context.Context, input *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	return func(ctx context.Context, input *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+		out := &ssm.GetParametersOutput{}
+		for _, n := range input.Names {
+			found := false
+			for _, p := range parameters {
+				if p.Name == n {
+					out.Parameters = append(out.Parameters, types.Parameter{
+						Name:  &p.Name,
+						Value: &p.Value,
+						Type:  types.ParameterType(p.Type),
+					})
+					found = true
+					break
+				}
+			}
+			if !found {
+				out.InvalidParameters = append(out.InvalidParameters, n)
+			}
+		}
+		return out, nil
+	}
+}
+
+func Test_NewMultiRegion(t *testing.T) {
+	tests := map[string]struct {
+		primary     *Client
+		secondaries []*Client
+		options     []MultiRegionOption
+		err         error
+	}{
+		"default": {
+			primary:     newTestRegionClient("ap-southeast-2", &mockSSMClient{}),
+			secondaries: []*Client{newTestRegionClient("ap-southeast-4", &mockSSMClient{})},
+		},
+		"with read timeout": {
+			primary: newTestRegionClient("ap-southeast-2", &mockSSMClient{}),
+			options: []MultiRegionOption{WithReadTimeout(time.Second)},
+		},
+		"catch missing primary": {
+			err: ErrMultiRegionMissingPrimary{},
+		},
+		"catch missing secondary": {
+			primary:     newTestRegionClient("ap-southeast-2", &mockSSMClient{}),
+			secondaries: []*Client{nil},
+			err:         ErrMultiRegionMissingSecondary{},
+		},
+		"catch duplicate region": {
+			primary:     newTestRegionClient("ap-southeast-2", &mockSSMClient{}),
+			secondaries: []*Client{newTestRegionClient("ap-southeast-2", &mockSSMClient{})},
+			err:         ErrMultiRegionDuplicateRegion{"ap-southeast-2"},
+		},
+		"catch negative read timeout": {
+			primary: newTestRegionClient("ap-southeast-2", &mockSSMClient{}),
+			options: []MultiRegionOption{WithReadTimeout(-time.Second)},
+			err:     ErrClientFailedToSetOption{errors.New("readTimeout must be greater than or equal to 0")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewMultiRegion(context.Background(), tt.primary, tt.secondaries, tt.options...)
+			if tt.err != nil {
+				if err == nil || err.Error() != tt.err.Error() {
+					t.Errorf("NewMultiRegion() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("NewMultiRegion() returned an error; error=%v", err)
+			}
+		})
+	}
+}
+
+func Test_MultiRegionPut(t *testing.T) {
+	tests := map[string]struct {
+		primary   *mockSSMClient
+		secondary *mockSSMClient
+		want      RegionResults
+	}{
+		"put parameters in every region": {
+			primary:   &mockSSMClient{PutParameterFunc: mockPutParameter("success")},
+			secondary: &mockSSMClient{PutParameterFunc: mockPutParameter("success")},
+			want: RegionResults{
+				{Region: "ap-southeast-2"},
+				{Region: "ap-southeast-4"},
+			},
+		},
+		"report failed region": {
+			primary:   &mockSSMClient{PutParameterFunc: mockPutParameter("success")},
+			secondary: &mockSSMClient{PutParameterFunc: mockPutParameter("error")},
+			want: RegionResults{
+				{Region: "ap-southeast-4"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewMultiRegion(
+				context.Background(),
+				newTestRegionClient("ap-southeast-2", tt.primary),
+				[]*Client{newTestRegionClient("ap-southeast-4", tt.secondary)},
+			)
+			if err != nil {
+				t.Fatalf("NewMultiRegion() returned an error; error=%v", err)
+			}
+			got, errs := m.Put(context.Background(), validTestdata.toParameters())
+			if failed := got.Failed(); len(failed) > 0 {
+				if errs == nil {
+					t.Errorf("Put() returned failed regions without an error; got=%+v", failed)
+				}
+				got = failed
+				for i := range got {
+					got[i].Err = nil
+				}
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Put() returned unexpected results;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MultiRegionGetMultiple(t *testing.T) {
+	tests := map[string]struct {
+		primary   *mockSSMClient
+		secondary *mockSSMClient
+		names     []string
+		want      Parameters
+		err       bool
+	}{
+		"get parameters from primary": {
+			primary:   &mockSSMClient{GetParametersFunc: mockGetParameters("success")},
+			secondary: &mockSSMClient{GetParametersFunc: mockGetParameters("error")},
+			names:     validTestdata.toSliceString(),
+			want:      validTestdata.toParameters(),
+		},
+		"failover to secondary": {
+			primary:   &mockSSMClient{GetParametersFunc: mockGetParameters("error")},
+			secondary: &mockSSMClient{GetParametersFunc: mockGetParameters("success")},
+			names:     validTestdata.toSliceString(),
+			want:      validTestdata.toParameters(),
+		},
+		"don't failover on invalid parameters": {
+			primary:   &mockSSMClient{GetParametersFunc: mockGetParameters("invalid")},
+			secondary: &mockSSMClient{GetParametersFunc: mockGetParameters("success")},
+			names:     validTestdata.toSliceString(),
+			err:       true,
+		},
+		"catch every region failing": {
+			primary:   &mockSSMClient{GetParametersFunc: mockGetParameters("error")},
+			secondary: &mockSSMClient{GetParametersFunc: mockGetParameters("error")},
+			names:     validTestdata.toSliceString(),
+			err:       true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewMultiRegion(
+				context.Background(),
+				newTestRegionClient("ap-southeast-2", tt.primary),
+				[]*Client{newTestRegionClient("ap-southeast-4", tt.secondary)},
+			)
+			if err != nil {
+				t.Fatalf("NewMultiRegion() returned an error; error=%v", err)
+			}
+			got, err := m.GetMultiple(context.Background(), tt.names...)
+			if (err != nil) != tt.err {
+				t.Errorf("GetMultiple() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("GetMultiple() returned unexpected parameters;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MultiRegionDrift(t *testing.T) {
+	params := validTestdata.toParameters()
+	changed := append(Parameters{}, params...)
+	changed[1].Value = "this is different"
+	changed[2].Type = ParameterTypeString
+	tests := map[string]struct {
+		primary   Parameters
+		secondary Parameters
+		want      RegionalDrifts
+	}{
+		"no drift": {
+			primary:   params,
+			secondary: params,
+		},
+		"detect missing parameter": {
+			primary:   params,
+			secondary: params[1:],
+			want: RegionalDrifts{
+				{Name: params[0].Name, Region: "ap-southeast-4", Kind: DriftKindMissing},
+			},
+		},
+		"detect unexpected parameter": {
+			primary:   params[1:],
+			secondary: params,
+			want: RegionalDrifts{
+				{Name: params[0].Name, Region: "ap-southeast-4", Kind: DriftKindUnexpected},
+			},
+		},
+		"detect mismatched parameters": {
+			primary:   params,
+			secondary: changed,
+			want: RegionalDrifts{
+				{Name: params[1].Name, Region: "ap-southeast-4", Kind: DriftKindValueMismatch},
+				{Name: params[2].Name, Region: "ap-southeast-4", Kind: DriftKindTypeMismatch},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewMultiRegion(
+				context.Background(),
+				newTestRegionClient("ap-southeast-2", &mockSSMClient{
+					GetParametersFunc: mockGetParametersFrom(tt.primary),
+				}),
+				[]*Client{newTestRegionClient("ap-southeast-4", &mockSSMClient{
+					GetParametersFunc: mockGetParametersFrom(tt.secondary),
+				})},
+			)
+			if err != nil {
+				t.Fatalf("NewMultiRegion() returned an error; error=%v", err)
+			}
+			got, err := m.Drift(context.Background(), params.ToSliceString()...)
+			if err != nil {
+				t.Errorf("Drift() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Drift() returned unexpected drift;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
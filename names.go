@@ -0,0 +1,202 @@
+package paramstore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// cleanPrefix validates and normalizes the given prefix, so that it starts
+// with a "/" and doesn't end with one. An empty prefix is returned as-is.
+func cleanPrefix(prefix string) (string, error) {
+	if prefix == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(prefix, "arn:") {
+		return "", ErrInvalidPrefix{prefix, "prefix cannot be an ARN"}
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		return "", ErrInvalidPrefix{prefix, "prefix must start with \"/\""}
+	}
+	if hasParentSegment(prefix) {
+		return "", ErrInvalidPrefix{prefix, "prefix cannot contain \"..\""}
+	}
+	return strings.TrimRight(prefix, "/"), nil
+}
+
+// hasParentSegment reports whether the given name contains a ".." segment.
+func hasParentSegment(name string) bool {
+	for _, s := range strings.Split(name, "/") {
+		if s == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// the max length of a parameter name.
+	maxNameLength = 1011
+
+	// the max number of levels in a parameter hierarchy.
+	maxNameDepth = 15
+
+	// the placeholder in a name template that is replaced by the given name.
+	nameTemplateKey = "{key}"
+)
+
+var (
+	// the characters allowed in a parameter name.
+	nameCharacters = regexp.MustCompile(`^[a-zA-Z0-9_.\-/]+$`)
+
+	// a variable in a name, such as "{env}".
+	nameVariable = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+)
+
+// validateName checks the given name against the naming rules used by AWS SSM
+// Parameter Store, returning the first rule the name breaks.
+func validateName(name string) error {
+	if violations := nameViolations(name); len(violations) > 0 {
+		return violations[0]
+	}
+	return nil
+}
+
+// nameViolations checks the given name against the naming rules used by AWS
+// SSM Parameter Store, returning every rule the name breaks.
+func nameViolations(name string) (out []error) {
+	if name == "" {
+		return []error{ErrInvalidName{name, "name cannot be empty"}}
+	}
+	if len(name) > maxNameLength {
+		out = append(out, ErrInvalidName{name, fmt.Sprintf("name cannot be longer than %v characters", maxNameLength)})
+	}
+	if !nameCharacters.MatchString(name) {
+		out = append(out, ErrInvalidName{name, "name can only contain letters, numbers and the symbols \"_.-/\""})
+	}
+	if strings.Contains(name, "/") && !strings.HasPrefix(name, "/") {
+		out = append(out, ErrInvalidName{name, "hierarchical names must start with \"/\""})
+	}
+	if strings.Count(name, "/") > maxNameDepth {
+		out = append(out, ErrInvalidName{name, fmt.Sprintf("name cannot have more than %v levels", maxNameDepth)})
+	}
+	return out
+}
+
+// expand converts a name given to this client into a name that follows the
+// name template configured for this client, replacing any variables in the
+// name with the variables configured for this client.
+func (c *Client) expand(name string) (string, error) {
+	if c.nameTemplate == "" && !nameVariable.MatchString(name) {
+		return name, nil
+	}
+
+	// apply template.
+	if c.nameTemplate != "" {
+		name = strings.ReplaceAll(c.nameTemplate, nameTemplateKey, strings.TrimLeft(name, "/"))
+	}
+
+	// replace variables.
+	var unresolved []string
+	expanded := nameVariable.ReplaceAllStringFunc(name, func(v string) string {
+		value, ok := c.nameVariables[v[1:len(v)-1]]
+		if !ok {
+			unresolved = append(unresolved, v)
+			return v
+		}
+		return value
+	})
+	if len(unresolved) > 0 {
+		return "", ErrUnresolvedNameVariables{name, unresolved}
+	}
+	if err := validateName(expanded); err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
+
+// qualify converts a name given to this client into the name used in AWS SSM
+// Parameter Store, by expanding the name template and adding the prefix
+// configured for this client.
+func (c *Client) qualify(name string) (string, error) {
+	name, err := c.expand(name)
+	if err != nil {
+		return "", err
+	}
+	if c.prefix == "" {
+		return name, nil
+	}
+	if strings.HasPrefix(name, "arn:") || hasParentSegment(name) {
+		return "", ErrNameEscapesPrefix{name, c.prefix}
+	}
+	return c.prefix + "/" + strings.TrimLeft(name, "/"), nil
+}
+
+// qualifyAll converts the given names using qualify, returning every error
+// encountered. A map of each converted name to the name given is also
+// returned, so names returned by AWS SSM Parameter Store can be converted back.
+func (c *Client) qualifyAll(names []string) (out []string, given map[string]string, errs error) {
+	out = make([]string, 0, len(names))
+	given = make(map[string]string, len(names))
+	for _, n := range names {
+		q, err := c.qualify(n)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		out = append(out, q)
+		given[q] = n
+	}
+	return out, given, errs
+}
+
+// unqualifyFrom converts a name used in AWS SSM Parameter Store back into the
+// name given to this client, using the map returned by qualifyAll.
+func (c *Client) unqualifyFrom(given map[string]string, name string) string {
+	if n, ok := given[name]; ok {
+		return n
+	}
+	return c.unqualify(name)
+}
+
+// unqualify converts a name used in AWS SSM Parameter Store back into the name
+// given to this client, by removing the prefix and reversing the name template
+// configured for this client, so that qualify(unqualify(name)) == name.
+// NOTE: variables given in a name, rather than in the name template, can't be
+// recovered, so they're left expanded.
+func (c *Client) unqualify(name string) string {
+	if c.prefix != "" {
+		name = strings.TrimPrefix(name, c.prefix)
+	}
+	if c.nameTemplate == "" {
+		return name
+	}
+
+	// reverse template.
+	head, tail, ok := c.templateParts()
+	key := strings.TrimLeft(name, "/")
+	if !ok || !strings.HasPrefix(key, head) || !strings.HasSuffix(key[len(head):], tail) {
+		return name
+	}
+	return "/" + strings.TrimSuffix(key[len(head):], tail)
+}
+
+// templateParts returns the (expanded) parts of the name template configured
+// for this client before and after the "{key}" placeholder, without a leading
+// "/". False is returned if either part has unresolved variables.
+func (c *Client) templateParts() (head, tail string, ok bool) {
+	before, after, _ := strings.Cut(strings.TrimLeft(c.nameTemplate, "/"), nameTemplateKey)
+	ok = true
+	replace := func(v string) string {
+		value, found := c.nameVariables[v[1:len(v)-1]]
+		if !found {
+			ok = false
+		}
+		return value
+	}
+	head = nameVariable.ReplaceAllStringFunc(before, replace)
+	tail = nameVariable.ReplaceAllStringFunc(after, replace)
+	return head, tail, ok
+}
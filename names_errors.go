@@ -0,0 +1,49 @@
+package paramstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPrefix is returned when a prefix given to a client is invalid.
+type ErrInvalidPrefix struct {
+	prefix string
+	reason string
+}
+
+func (e ErrInvalidPrefix) Error() string {
+	return fmt.Sprintf("invalid prefix %q: %v", e.prefix, e.reason)
+}
+
+// ErrNameEscapesPrefix is returned when a name given to a client with a prefix
+// would resolve to a parameter outside of that prefix.
+type ErrNameEscapesPrefix struct {
+	name   string
+	prefix string
+}
+
+func (e ErrNameEscapesPrefix) Error() string {
+	return fmt.Sprintf("%q escapes the prefix %q", e.name, e.prefix)
+}
+
+// ErrInvalidName is returned when a name doesn't follow the naming rules used
+// by AWS SSM Parameter Store.
+type ErrInvalidName struct {
+	name   string
+	reason string
+}
+
+func (e ErrInvalidName) Error() string {
+	return fmt.Sprintf("invalid name %q: %v", e.name, e.reason)
+}
+
+// ErrUnresolvedNameVariables is returned when a name contains variables that
+// haven't been configured for the client.
+type ErrUnresolvedNameVariables struct {
+	name      string
+	variables []string
+}
+
+func (e ErrUnresolvedNameVariables) Error() string {
+	return fmt.Sprintf("unresolved variables in name %q: %v", e.name, strings.Join(e.variables, ", "))
+}
@@ -0,0 +1,160 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func Test_qualify(t *testing.T) {
+	vars := map[string]string{"org": "acme", "env": "prod", "service": "api"}
+	tests := map[string]struct {
+		client *Client
+		name   string
+		want   string
+		err    string
+	}{
+		"no prefix": {
+			client: &Client{},
+			name:   "/db/password",
+			want:   "/db/password",
+		},
+		"prefix with absolute name": {
+			client: &Client{prefix: "/myapp/prod"},
+			name:   "/db/password",
+			want:   "/myapp/prod/db/password",
+		},
+		"prefix with relative name": {
+			client: &Client{prefix: "/myapp/prod"},
+			name:   "db/password",
+			want:   "/myapp/prod/db/password",
+		},
+		"template": {
+			client: &Client{nameTemplate: "/{org}/{env}/{service}/{key}", nameVariables: vars},
+			name:   "db/password",
+			want:   "/acme/prod/api/db/password",
+		},
+		"template with prefix": {
+			client: &Client{prefix: "/shared", nameTemplate: "/{env}/{key}", nameVariables: vars},
+			name:   "/db/password",
+			want:   "/shared/prod/db/password",
+		},
+		"template with a suffix": {
+			client: &Client{nameTemplate: "/{env}/{key}/value", nameVariables: vars},
+			name:   "db/password",
+			want:   "/prod/db/password/value",
+		},
+		"variables without template": {
+			client: &Client{nameVariables: vars},
+			name:   "/{env}/db/password",
+			want:   "/prod/db/password",
+		},
+		"catch name escaping prefix": {
+			client: &Client{prefix: "/myapp/prod"},
+			name:   "/../staging/db/password",
+			err:    `"/../staging/db/password" escapes the prefix "/myapp/prod"`,
+		},
+		"catch arn": {
+			client: &Client{prefix: "/myapp/prod"},
+			name:   "arn:aws:ssm:ap-southeast-2:123456789012:parameter/db/password",
+			err:    `"arn:aws:ssm:ap-southeast-2:123456789012:parameter/db/password" escapes the prefix "/myapp/prod"`,
+		},
+		"catch unresolved variables": {
+			client: &Client{nameTemplate: "/{org}/{region}/{key}", nameVariables: vars},
+			name:   "db/{user}",
+			err:    `unresolved variables in name "/{org}/{region}/db/{user}": {region}, {user}`,
+		},
+		"catch invalid characters": {
+			client: &Client{nameTemplate: "/{org}/{key}", nameVariables: map[string]string{"org": "a cme"}},
+			name:   "db",
+			err:    `invalid name "/a cme/db": name can only contain letters, numbers and the symbols "_.-/"`,
+		},
+		"catch too many levels": {
+			client: &Client{nameTemplate: "/{env}/{key}", nameVariables: vars},
+			name:   "a/b/c/d/e/f/g/h/i/j/k/l/m/n/o",
+			err:    `invalid name "/prod/a/b/c/d/e/f/g/h/i/j/k/l/m/n/o": name cannot have more than 15 levels`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.client.qualify(tt.name)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("qualify() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("qualify() returned an error; error=%v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("qualify() returned unexpected name; want=%v, got=%v", tt.want, got)
+			}
+			back := tt.client.unqualify(got)
+			if again, _ := tt.client.qualify(back); again != got {
+				t.Errorf("qualify() didn't reverse unqualify(); want=%v, got=%v", got, again)
+			}
+			if nameVariable.MatchString(tt.name) {
+				return // variables given in a name can't be recovered.
+			}
+			if want := "/" + strings.TrimLeft(tt.name, "/"); back != want {
+				t.Errorf("unqualify() returned unexpected name; want=%v, got=%v", want, back)
+			}
+		})
+	}
+}
+
+func Test_GetMultipleWithPrefix(t *testing.T) {
+	var requested []string
+	c := &Client{
+		logger:    slog.Default(),
+		batchSize: 10,
+		prefix:    "/myapp/prod",
+		ssmsvc: &mockSSMClient{
+			GetParametersFunc: func(ctx context.Context, input *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+				requested = append(requested, input.Names...)
+				return mockGetParametersFrom(Parameters{
+					{Name: "/myapp/prod/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+				})(ctx, input, optFns...)
+			},
+		},
+	}
+	got, err := c.GetMultiple(context.Background(), "/db/password")
+	if err != nil {
+		t.Fatalf("GetMultiple() returned an error; error=%v", err)
+	}
+	if want := []string{"/myapp/prod/db/password"}; !reflect.DeepEqual(want, requested) {
+		t.Errorf("GetMultiple() requested unexpected names; want=%v, got=%v", want, requested)
+	}
+	want := Parameters{{Name: "/db/password", Value: "hunter2", Type: ParameterTypeSecureString}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("GetMultiple() returned unexpected parameters;\nwant=%+v\ngot=%+v\n", want, got)
+	}
+}
+
+func Test_GetMultipleWithNameTemplate(t *testing.T) {
+	c := &Client{
+		logger:        slog.Default(),
+		batchSize:     10,
+		nameTemplate:  "/{env}/{key}",
+		nameVariables: map[string]string{"env": "prod"},
+		ssmsvc: &mockSSMClient{
+			GetParametersFunc: mockGetParametersFrom(Parameters{
+				{Name: "/prod/db/host", Value: "db.prod", Type: ParameterTypeString},
+			}),
+		},
+	}
+	got, err := c.GetMultiple(context.Background(), "db/host")
+	if err != nil {
+		t.Fatalf("GetMultiple() returned an error; error=%v", err)
+	}
+	want := Parameters{{Name: "db/host", Value: "db.prod", Type: ParameterTypeString}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("GetMultiple() returned unexpected parameters;\nwant=%+v\ngot=%+v\n", want, got)
+	}
+}
@@ -1,6 +1,11 @@
 package paramstore
 
-import "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
 
 // ParameterType is a thin wrapper over ssm/types.ParameterType.
 // NOTE:
@@ -14,12 +19,68 @@ const (
 	ParameterTypeSecureString ParameterType = ParameterType(types.ParameterTypeSecureString)
 )
 
+// ParameterTier is a thin wrapper over ssm/types.ParameterTier.
+type ParameterTier types.ParameterTier
+
+const (
+	ParameterTierStandard           ParameterTier = ParameterTier(types.ParameterTierStandard)
+	ParameterTierAdvanced           ParameterTier = ParameterTier(types.ParameterTierAdvanced)
+	ParameterTierIntelligentTiering ParameterTier = ParameterTier(types.ParameterTierIntelligentTiering)
+)
+
 // Parameter is a thin wrapper over ssm/types.Parameter.
 type Parameter struct {
 	Name      string        // The name of the parameter.
 	Value     string        // The value of the parameter.
 	Type      ParameterType // The type of the parameter.
+	Tier      ParameterTier // The tier of the parameter, used during Put().
 	Overwrite bool          // Used to overwrite existing parameters during Put().
+
+	// optional, used during Put() if set.
+	Description string // The description of the parameter.
+	KeyId       string // The KMS key used to encrypt a SecureString parameter; overrides the client's key.
+	Codec       Codec  // The codec used to encode the value; overrides the client's codecs.
+
+	// metadata, populated when retrieving parameters.
+	Selector         string    // The version or label selector used to retrieve the parameter (eg. ":1").
+	Version          int64     // The version of the parameter.
+	LastModifiedDate time.Time // The date the parameter was last modified.
+}
+
+// newParameter converts a ssm/types.Parameter into a Parameter, using the
+// given name rather than the name in AWS SSM Parameter Store.
+func newParameter(name string, p types.Parameter) Parameter {
+	out := Parameter{
+		Name:    name,
+		Type:    ParameterType(p.Type),
+		Version: p.Version,
+	}
+
+	// there's a very slim change the Value is missing.
+	if p.Value != nil {
+		out.Value = *p.Value
+	}
+	if p.LastModifiedDate != nil {
+		out.LastModifiedDate = *p.LastModifiedDate
+	}
+	if p.Selector != nil {
+		out.Selector = selector(*p.Selector)
+	}
+	return out
+}
+
+// matches returns true if putting this param would leave the given current
+// param unchanged. The type, tier and description are only compared if set.
+func (p Parameter) matches(current Parameter) bool {
+	switch {
+	case
+		p.Value != current.Value,
+		p.Type != "" && p.Type != current.Type,
+		p.Tier != "" && p.Tier != current.Tier,
+		p.Description != "" && p.Description != current.Description:
+		return false
+	}
+	return true
 }
 
 // Parameters is a slice of Parameter.
@@ -32,3 +93,12 @@ func (parameters Parameters) ToSliceString() (out []string) {
 	}
 	return out
 }
+
+// selector normalizes the given version or label selector, so that it always
+// starts with a ":".
+func selector(s string) string {
+	if s == "" || strings.HasPrefix(s, ":") {
+		return s
+	}
+	return ":" + s
+}
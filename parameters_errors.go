@@ -0,0 +1,56 @@
+package paramstore
+
+import "fmt"
+
+// ErrInvalidValue is returned when the value of a parameter doesn't follow the
+// rules used by AWS SSM Parameter Store.
+type ErrInvalidValue struct {
+	name   string
+	reason string
+}
+
+func (e ErrInvalidValue) Error() string {
+	return fmt.Sprintf("invalid value for %q: %v", e.name, e.reason)
+}
+
+// ErrInvalidType is returned when the type of a parameter isn't supported by
+// AWS SSM Parameter Store.
+type ErrInvalidType struct {
+	name string
+	t    ParameterType
+}
+
+func (e ErrInvalidType) Error() string {
+	return fmt.Sprintf("invalid type for %q: %q is not a parameter type", e.name, e.t)
+}
+
+// ErrInvalidTier is returned when the tier of a parameter isn't supported by
+// AWS SSM Parameter Store.
+type ErrInvalidTier struct {
+	name string
+	tier ParameterTier
+}
+
+func (e ErrInvalidTier) Error() string {
+	return fmt.Sprintf("invalid tier for %q: %q is not a parameter tier", e.name, e.tier)
+}
+
+// ErrInvalidDescription is returned when the description of a parameter
+// doesn't follow the rules used by AWS SSM Parameter Store.
+type ErrInvalidDescription struct {
+	name   string
+	reason string
+}
+
+func (e ErrInvalidDescription) Error() string {
+	return fmt.Sprintf("invalid description for %q: %v", e.name, e.reason)
+}
+
+// ErrDuplicateName is returned when the same name is given more than once.
+type ErrDuplicateName struct {
+	name string
+}
+
+func (e ErrDuplicateName) Error() string {
+	return fmt.Sprintf("%q is given more than once", e.name)
+}
@@ -0,0 +1,158 @@
+package paramstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// GetByPath retrieves every param under the given path from paramstore. If
+// recursive is true, params nested deeper than one level below the path are
+// also retrieved.
+func (c *Client) GetByPath(ctx context.Context, path string, recursive bool) (out Parameters, err error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "GetByPath")
+	defer span.End()
+
+	// qualify path.
+	qualified, err := c.qualifyPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve params, one page at a time.
+	in := &ssm.GetParametersByPathInput{
+		Path:           aws.String(qualified),
+		Recursive:      aws.Bool(recursive),
+		WithDecryption: &c.withDecryption,
+		MaxResults:     aws.Int32(int32(c.batchSize)),
+	}
+	chunks := make(map[string]string)
+	var names []string // The qualified name of each param in out.
+	paginator := ssm.NewGetParametersByPathPaginator(c.ssmsvc, in)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(newCtx)
+		if err != nil {
+			c.logger.Error("failed to get parameters by path",
+				"error", err,
+				"path", qualified,
+				"recursive", recursive,
+				"decryption", c.withDecryption,
+			)
+			return nil, err
+		}
+		for _, p := range resp.Parameters {
+			if c.largeValues && isChunk(*p.Name) {
+				chunks[*p.Name] = aws.ToString(p.Value)
+				continue
+			}
+			out = append(out, newParameter(c.unqualify(*p.Name), p))
+			names = append(names, *p.Name)
+		}
+	}
+
+	// reassemble values split into chunks, if needed.
+	if c.largeValues {
+		if err := c.assemble(newCtx, out, names, chunks); err != nil {
+			return nil, err
+		}
+	}
+
+	// decrypt values, if needed.
+	if err := c.decryptAll(newCtx, out, names); err != nil {
+		return nil, err
+	}
+
+	// decode values.
+	if err := c.decodeAll(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// describeByPath retrieves the metadata of every param under the given path
+// from paramstore, including the tier, description and KMS key that
+// GetByPath() doesn't return, keyed by name.
+func (c *Client) describeByPath(ctx context.Context, path string) (map[string]types.ParameterMetadata, error) {
+
+	// qualify path.
+	qualified, err := c.qualifyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.describe(ctx, types.ParameterStringFilter{
+		Key:    aws.String("Path"),
+		Option: aws.String("Recursive"),
+		Values: []string{qualified},
+	})
+}
+
+// describeName retrieves the metadata of a single param from paramstore, in
+// the same way as describeByPath.
+func (c *Client) describeName(ctx context.Context, name string) (types.ParameterMetadata, bool, error) {
+
+	// qualify name.
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return types.ParameterMetadata{}, false, err
+	}
+	out, err := c.describe(ctx, types.ParameterStringFilter{
+		Key:    aws.String("Name"),
+		Option: aws.String("Equals"),
+		Values: []string{qualified},
+	})
+	if err != nil {
+		return types.ParameterMetadata{}, false, err
+	}
+	m, ok := out[c.unqualify(qualified)]
+	return m, ok, nil
+}
+
+// describe retrieves the metadata of every param matching the given filter
+// from paramstore, keyed by name.
+func (c *Client) describe(ctx context.Context, filter types.ParameterStringFilter) (map[string]types.ParameterMetadata, error) {
+
+	// retrieve metadata, one page at a time.
+	in := &ssm.DescribeParametersInput{
+		ParameterFilters: []types.ParameterStringFilter{filter},
+		MaxResults:       aws.Int32(int32(c.batchSize)),
+	}
+	out := make(map[string]types.ParameterMetadata)
+	paginator := ssm.NewDescribeParametersPaginator(c.ssmsvc, in)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			c.logger.Error("failed to describe parameters",
+				"error", err,
+				"filter", aws.ToString(filter.Key),
+				"values", filter.Values,
+			)
+			return nil, err
+		}
+		for _, p := range resp.Parameters {
+			out[c.unqualify(*p.Name)] = p
+		}
+	}
+	return out, nil
+}
+
+// qualifyPath converts a path given to this client into the path used in AWS
+// SSM Parameter Store, in the same way as qualify. An empty path is treated as
+// the root path.
+func (c *Client) qualifyPath(path string) (string, error) {
+	if path == "" {
+		path = "/"
+	}
+	qualified, err := c.qualify(path)
+	if err != nil {
+		return "", err
+	}
+	if qualified != "/" {
+		qualified = strings.TrimRight(qualified, "/")
+	}
+	return qualified, nil
+}
@@ -0,0 +1,63 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_GetByPath(t *testing.T) {
+	store := Parameters{
+		{Name: "/myapp/prod/db/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/prod/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+		{Name: "/myapp/prod/name", Value: "myapp", Type: ParameterTypeString},
+		{Name: "/myapp/staging/name", Value: "myapp-staging", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		prefix    string
+		path      string
+		recursive bool
+		want      []string
+	}{
+		"get parameters under path": {
+			path: "/myapp/prod",
+			want: []string{"/myapp/prod/name"},
+		},
+		"get parameters under path (recursive)": {
+			path:      "/myapp/prod/",
+			recursive: true,
+			want:      []string{"/myapp/prod/db/host", "/myapp/prod/db/password", "/myapp/prod/name"},
+		},
+		"get parameters under path with prefix": {
+			prefix:    "/myapp/prod",
+			path:      "/db",
+			recursive: true,
+			want:      []string{"/db/host", "/db/password"},
+		},
+		"get parameters under root path with prefix": {
+			prefix:    "/myapp/staging",
+			recursive: true,
+			want:      []string{"/name"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(store)
+			c := &Client{
+				logger:    slog.Default(),
+				batchSize: 2,
+				prefix:    tt.prefix,
+				ssmsvc:    mock,
+			}
+			got, err := c.GetByPath(context.Background(), tt.path, tt.recursive)
+			if err != nil {
+				t.Errorf("GetByPath() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got.ToSliceString()) {
+				t.Errorf("GetByPath() returned unexpected parameters;\nwant=%v\ngot=%v\n", tt.want, got.ToSliceString())
+			}
+		})
+	}
+}
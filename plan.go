@@ -0,0 +1,269 @@
+package paramstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// ChangeAction is the action a Change (or an Outcome) makes to a parameter.
+type ChangeAction string
+
+const (
+	ChangeActionCreate  ChangeAction = "create"  // The parameter doesn't exist, and will be created.
+	ChangeActionUpdate  ChangeAction = "update"  // The parameter exists, and will be overwritten.
+	ChangeActionDelete  ChangeAction = "delete"  // The parameter exists, but isn't desired, and will be deleted.
+	ChangeActionNoop    ChangeAction = "no-op"   // The parameter exists, and already matches.
+	ChangeActionMissing ChangeAction = "missing" // The parameter doesn't exist, so can't be deleted.
+)
+
+// Change is a single change to a parameter in a Plan.
+type Change struct {
+	Action  ChangeAction
+	Name    string
+	Current *Parameter // The parameter as it is now; nil when creating.
+	Desired *Parameter // The parameter as it will be put; nil when deleting.
+	Fields  []string   // The fields that differ when updating (value, type, tier or description).
+}
+
+// Plan is the set of changes needed to make the parameters under a path match
+// a desired set of parameters.
+type Plan struct {
+	Path    string
+	Changes []Change
+}
+
+// PlanOptions configures how a Plan is made.
+type PlanOptions struct {
+	Delete bool // If true, parameters under the path that aren't desired are deleted.
+}
+
+// Empty returns true if the plan has no changes.
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Plan compares the desired parameters against every parameter under the
+// given path, and returns the changes needed to make them match. The type,
+// tier and description of a desired parameter are only compared if set.
+// NOTE: SecureString values are always decrypted when comparing them, even if
+// this client isn't configured to decrypt them.
+func (c *Client) Plan(ctx context.Context, desired Parameters, path string, opts PlanOptions) (plan Plan, errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Plan")
+	defer span.End()
+
+	// validate desired params, before making any calls.
+	plan.Path = path
+	base := strings.TrimRight(path, "/") + "/"
+	for _, p := range desired {
+		if !strings.HasPrefix(p.Name, base) {
+			errs = multierror.Append(errs, ErrNameOutsidePath{p.Name, path})
+		}
+		if p.Type == ParameterTypeSecureString && isRedacted(p.Value) {
+			errs = multierror.Append(errs, ErrRedactedValue{p.Name})
+		}
+	}
+	if _, _, _, err := c.prepare(newCtx, desired); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if errs != nil {
+		return plan, errs
+	}
+
+	// retrieve current params, with metadata.
+	decrypting := *c
+	decrypting.withDecryption = true
+	found, err := decrypting.GetByPath(newCtx, path, true)
+	if err != nil {
+		return plan, err
+	}
+	metadata, err := c.describeByPath(newCtx, path)
+	if err != nil {
+		return plan, err
+	}
+	current := make(map[string]Parameter, len(found))
+	for _, p := range found {
+		if m, ok := metadata[p.Name]; ok {
+			p.Tier = ParameterTier(m.Tier)
+			if m.Description != nil {
+				p.Description = *m.Description
+			}
+			if m.KeyId != nil {
+				p.KeyId = *m.KeyId
+			}
+		}
+		current[p.Name] = p
+	}
+
+	// determine creates and updates.
+	wanted := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		d := d
+		wanted[d.Name] = true
+		cur, ok := current[d.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ChangeActionCreate, Name: d.Name, Desired: &d})
+			continue
+		}
+		var fields []string
+		if d.Value != cur.Value {
+			fields = append(fields, "value")
+		}
+		if d.Type == "" {
+			d.Type = cur.Type
+		} else if d.Type != cur.Type {
+			fields = append(fields, "type")
+		}
+		if d.Type == ParameterTypeSecureString && isRedacted(d.Value) {
+			errs = multierror.Append(errs, ErrRedactedValue{d.Name})
+			continue
+		}
+		if d.Tier == "" {
+			d.Tier = cur.Tier
+		} else if d.Tier != cur.Tier {
+			fields = append(fields, "tier")
+		}
+		if d.Description != "" && d.Description != cur.Description {
+			fields = append(fields, "description")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		d.Overwrite = true
+		plan.Changes = append(plan.Changes, Change{
+			Action:  ChangeActionUpdate,
+			Name:    d.Name,
+			Current: &cur,
+			Desired: &d,
+			Fields:  fields,
+		})
+	}
+	if errs != nil {
+		return plan, errs
+	}
+
+	// determine deletes.
+	if opts.Delete {
+		for name, cur := range current {
+			cur := cur
+			if !wanted[name] {
+				plan.Changes = append(plan.Changes, Change{Action: ChangeActionDelete, Name: name, Current: &cur})
+			}
+		}
+	}
+	sort.Slice(plan.Changes, func(i, j int) bool { return plan.Changes[i].Name < plan.Changes[j].Name })
+	return plan, nil
+}
+
+// Apply executes the changes in the given plan, putting every created and
+// updated parameter, then deleting every deleted parameter.
+func (c *Client) Apply(ctx context.Context, plan Plan) (errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Apply")
+	defer span.End()
+
+	// split changes.
+	var puts Parameters
+	var deletes []string
+	for _, ch := range plan.Changes {
+		switch ch.Action {
+		case ChangeActionCreate, ChangeActionUpdate:
+			puts = append(puts, *ch.Desired)
+		case ChangeActionDelete:
+			deletes = append(deletes, ch.Name)
+		}
+	}
+
+	// put params.
+	if len(puts) > 0 {
+		if err := c.Put(newCtx, puts); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	// delete params.
+	if len(deletes) > 0 {
+		if err := c.Delete(newCtx, deletes...); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Diff writes a human-readable summary of the changes in the plan to the
+// given writer. SecureString values are always redacted, using the given
+// policy.
+func (p Plan) Diff(w io.Writer, policy RedactionPolicy) error {
+	for _, ch := range p.Changes {
+		var err error
+		switch ch.Action {
+		case ChangeActionCreate:
+			secure := ch.Desired.Type == ParameterTypeSecureString
+			_, err = fmt.Fprintf(w, "+ %v (%v) = %q\n", ch.Name, ch.Desired.Type, diffValue(ch.Desired.Value, secure, policy))
+		case ChangeActionDelete:
+			_, err = fmt.Fprintf(w, "- %v\n", ch.Name)
+		case ChangeActionUpdate:
+			_, err = fmt.Fprintf(w, "~ %v\n", ch.Name)
+			secure := ch.Current.Type == ParameterTypeSecureString || ch.Desired.Type == ParameterTypeSecureString
+			for _, f := range ch.Fields {
+				if err != nil {
+					break
+				}
+				var from, to string
+				switch f {
+				case "value":
+					from, to = diffValue(ch.Current.Value, secure, policy), diffValue(ch.Desired.Value, secure, policy)
+				case "type":
+					from, to = string(ch.Current.Type), string(ch.Desired.Type)
+				case "tier":
+					from, to = string(ch.Current.Tier), string(ch.Desired.Tier)
+				case "description":
+					from, to = ch.Current.Description, ch.Desired.Description
+				}
+				_, err = fmt.Fprintf(w, "    %v: %q => %q\n", f, from, to)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%v\n", p.summary())
+	return err
+}
+
+// String returns the output of Diff() as a string, with SecureString values
+// masked.
+func (p Plan) String() string {
+	var sb strings.Builder
+	p.Diff(&sb, RedactionMask)
+	return sb.String()
+}
+
+// summary returns a one line summary of the number of changes in the plan.
+func (p Plan) summary() string {
+	counts := make(map[ChangeAction]int)
+	for _, ch := range p.Changes {
+		counts[ch.Action]++
+	}
+	return fmt.Sprintf("%v to create, %v to update, %v to delete.",
+		counts[ChangeActionCreate],
+		counts[ChangeActionUpdate],
+		counts[ChangeActionDelete],
+	)
+}
+
+// diffValue returns the value to show in a diff, redacting the value using the
+// given policy if it's from a SecureString parameter.
+func diffValue(value string, secure bool, policy RedactionPolicy) string {
+	if secure {
+		return policy.redact(value)
+	}
+	return value
+}
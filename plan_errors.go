@@ -0,0 +1,14 @@
+package paramstore
+
+import "fmt"
+
+// ErrNameOutsidePath is returned when a desired parameter given to Plan() isn't
+// under the path being planned.
+type ErrNameOutsidePath struct {
+	name string
+	path string
+}
+
+func (e ErrNameOutsidePath) Error() string {
+	return fmt.Sprintf("%q is not under the path %q", e.name, e.path)
+}
@@ -0,0 +1,140 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_Plan(t *testing.T) {
+	store := Parameters{
+		{Name: "/myapp/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/password", Value: "hunter2", Type: ParameterTypeSecureString},
+		{Name: "/myapp/old", Value: "remove me", Type: ParameterTypeString},
+		{Name: "/other/name", Value: "other", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		desired Parameters
+		opts    PlanOptions
+		want    string
+		err     bool
+	}{
+		"no changes": {
+			desired: Parameters{
+				{Name: "/myapp/host", Value: "db.prod"},
+				{Name: "/myapp/password", Value: "hunter2", Type: ParameterTypeSecureString},
+			},
+			want: "0 to create, 0 to update, 0 to delete.\n",
+		},
+		"create, update and keep": {
+			desired: Parameters{
+				{Name: "/myapp/host", Value: "db.staging", Tier: ParameterTierAdvanced, Description: "The database host."},
+				{Name: "/myapp/new", Value: "hello", Type: ParameterTypeString},
+				{Name: "/myapp/password", Value: "hunter2", Type: ParameterTypeSecureString},
+			},
+			want: `~ /myapp/host
+    value: "db.prod" => "db.staging"
+    tier: "Standard" => "Advanced"
+    description: "" => "The database host."
++ /myapp/new (String) = "hello"
+1 to create, 1 to update, 0 to delete.
+`,
+		},
+		"mask SecureString values": {
+			desired: Parameters{
+				{Name: "/myapp/password", Value: "hunter3", Type: ParameterTypeSecureString},
+				{Name: "/myapp/token", Value: "abc123", Type: ParameterTypeSecureString},
+			},
+			want: `~ /myapp/password
+    value: "********" => "********"
++ /myapp/token (SecureString) = "********"
+1 to create, 1 to update, 0 to delete.
+`,
+		},
+		"delete parameters that aren't desired": {
+			desired: Parameters{
+				{Name: "/myapp/host", Value: "db.prod"},
+			},
+			opts: PlanOptions{Delete: true},
+			want: `- /myapp/old
+- /myapp/password
+0 to create, 0 to update, 2 to delete.
+`,
+		},
+		"catch name outside path": {
+			desired: Parameters{{Name: "/other/name", Value: "other"}},
+			err:     true,
+		},
+		"catch invalid parameter": {
+			desired: Parameters{{Name: "/myapp/empty"}},
+			err:     true,
+		},
+		"catch masked SecureString value": {
+			desired: Parameters{{Name: "/myapp/password", Value: maskedValue, Type: ParameterTypeSecureString}},
+			err:     true,
+		},
+		"catch hashed SecureString value": {
+			desired: Parameters{{Name: "/myapp/token", Value: "sha256:f52fbd32b2b3", Type: ParameterTypeSecureString}},
+			err:     true,
+		},
+		"catch redacted value of a SecureString parameter, without a type": {
+			desired: Parameters{{Name: "/myapp/password", Value: "<7 bytes>"}},
+			err:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 2, ssmsvc: mock}
+			plan, err := c.Plan(context.Background(), tt.desired, "/myapp", tt.opts)
+			if (err != nil) != tt.err {
+				t.Errorf("Plan() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				return
+			}
+			if tt.err {
+				return
+			}
+			if got := plan.String(); got != tt.want {
+				t.Errorf("Plan() returned an unexpected plan;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	mock, store := newMockSSMStore(Parameters{
+		{Name: "/myapp/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/old", Value: "remove me", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	ctx := context.Background()
+	desired := Parameters{
+		{Name: "/myapp/host", Value: "db.staging"},
+		{Name: "/myapp/new", Value: "hello", Type: ParameterTypeString},
+	}
+
+	// apply plan.
+	plan, err := c.Plan(ctx, desired, "/myapp", PlanOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("Plan() returned an error; error=%v", err)
+	}
+	if err := c.Apply(ctx, plan); err != nil {
+		t.Fatalf("Apply() returned an error; error=%v", err)
+	}
+	if want, got := []string{"/myapp/host", "/myapp/new"}, store.Names(); !reflect.DeepEqual(want, got) {
+		t.Errorf("Apply() left unexpected parameters; want=%v, got=%v", want, got)
+	}
+	if v, _ := store.Value("/myapp/host"); v != "db.staging" {
+		t.Errorf("Apply() didn't update parameter; want=%v, got=%v", "db.staging", v)
+	}
+
+	// re-plan, which should be empty.
+	plan, err = c.Plan(ctx, desired, "/myapp", PlanOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("Plan() returned an error; error=%v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Plan() returned changes after Apply();\n%v", plan)
+	}
+}
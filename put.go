@@ -7,33 +7,93 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	multierror "github.com/hashicorp/go-multierror"
-	"go.opentelemetry.io/otel"
 )
 
 // Put uploads one or more params to paramstore.
-func (c *Client) Put(ctx context.Context, parameters Parameters) (errs error) {
+func (c *Client) Put(ctx context.Context, parameters Parameters) (err error) {
 
 	// setup tracing.
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "Put")
-	defer span.End()
+	newCtx, span := c.startSpan(ctx, "Put", attrCount.Int(len(parameters)))
+	defer func() { endSpan(span, err) }()
 
-	for _, p := range parameters {
+	// upload params.
+	_, err = c.put(newCtx, parameters)
+	return err
+}
+
+// PutWithOutcomes uploads one or more params to paramstore, in the same way as
+// Put(), returning the outcome for each param. In dry-run mode, the current
+// params are retrieved to classify each change, but nothing is uploaded.
+func (c *Client) PutWithOutcomes(ctx context.Context, parameters Parameters) (out Outcomes, err error) {
+
+	// setup tracing.
+	newCtx, span := c.startSpan(ctx, "PutWithOutcomes", attrCount.Int(len(parameters)))
+	defer func() { endSpan(span, err) }()
 
-		// setup input.
-		in := &ssm.PutParameterInput{
-			Name:      aws.String(p.Name),
-			Value:     aws.String(p.Value),
-			Type:      types.ParameterType(p.Type),
-			Overwrite: aws.Bool(p.Overwrite),
+	// upload params.
+	return c.put(newCtx, parameters)
+}
+
+// put uploads one or more params to paramstore, returning the outcome for each
+// param.
+func (c *Client) put(ctx context.Context, parameters Parameters) (out Outcomes, errs error) {
+
+	// prepare params, before making any calls.
+	qualified, encoded, chunks, errs := c.prepare(ctx, parameters)
+	if errs != nil {
+		return nil, errs
+	}
+
+	// classify params, if needed.
+	out = make(Outcomes, len(qualified))
+	for i := range qualified {
+		out[i] = Outcome{Name: parameters[i].Name, Action: ChangeActionCreate}
+	}
+	dryRun := c.isDryRun(ctx)
+	if dryRun || c.idempotentPuts {
+		if err := c.classify(ctx, encoded, out); err != nil {
+			return nil, err
+		}
+	}
+
+	// log params, without uploading them.
+	if dryRun {
+		for i, p := range qualified {
+			c.logger.Info("dry run: would put parameter",
+				"name", p.Name,
+				"action", string(out[i].Action),
+			)
+			if out[i].Err != nil {
+				errs = multierror.Append(errs, out[i].Err)
+			}
 		}
+		return out, errs
+	}
+
+	for i, p := range qualified {
 
-		// add key id, if available.
-		if c.keyId != "" {
-			in.KeyId = aws.String(c.keyId)
+		// skip unchanged params.
+		switch {
+		case out[i].Err != nil:
+			errs = multierror.Append(errs, out[i].Err)
+			continue
+		case out[i].Action == ChangeActionNoop:
+			continue
+		}
+
+		// put chunks first, so a manifest never refers to missing chunks.
+		if ch, ok := chunks[p.Name]; ok {
+			if err := c.putChunks(ctx, ch); err != nil {
+				out[i].Err = err
+				errs = multierror.Append(errs, err)
+				continue
+			}
 		}
 
 		// put parameter.
-		_, err := c.ssmsvc.PutParameter(newCtx, in)
+		in := c.putInput(p)
+		callCtx, callSpan := c.startSpan(ctx, "PutParameter", attrCount.Int(1))
+		resp, err := c.ssmsvc.PutParameter(callCtx, in)
 		if err != nil {
 			c.logger.Error(
 				"failed to put parameter",
@@ -42,8 +102,174 @@ func (c *Client) Put(ctx context.Context, parameters Parameters) (errs error) {
 				"type", string(in.Type),
 				"overwrite", *in.Overwrite,
 			)
+			callSpan.SetAttributes(attrRetryCount.Int(errRetryCount(err)))
+			endSpan(callSpan, err)
+			out[i].Err = err
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		callSpan.SetAttributes(attrRetryCount.Int(retryCount(resp.ResultMetadata)))
+		callSpan.End()
+		out[i].Action = ChangeActionCreate
+		if resp.Version > 1 {
+			out[i].Action = ChangeActionUpdate
+		}
+
+		// delete chunks left over from a previous value, if needed.
+		if c.largeValues {
+			m, _ := parseManifest(p.Value)
+			if err := c.cleanupChunks(ctx, p.Name, m.Path); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	if c.idempotentPuts {
+		c.logger.Info("put parameters",
+			"created", out.Count(ChangeActionCreate),
+			"updated", out.Count(ChangeActionUpdate),
+			"unchanged", out.Count(ChangeActionNoop),
+		)
+	}
+	return out, errs
+}
+
+// prepare qualifies, encodes and encrypts a copy of the given params, then
+// splits any large values into chunks, validating the params as they'd be
+// uploaded. The encoded params, before being encrypted, are also returned, to
+// classify them.
+func (c *Client) prepare(ctx context.Context, parameters Parameters) (qualified, encoded Parameters, chunks map[string]Parameters, errs error) {
+
+	// qualify names.
+	qualified = make(Parameters, 0, len(parameters))
+	for _, p := range parameters {
+		name, err := c.qualify(p.Name)
+		if err != nil {
 			errs = multierror.Append(errs, err)
+			continue
+		}
+		p.Name = name
+		qualified = append(qualified, p)
+	}
+	if errs != nil {
+		return nil, nil, nil, errs
+	}
+
+	// encode values.
+	if err := c.encodeAll(qualified); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// encrypt values, if needed, keeping the encoded values to classify params.
+	encoded = qualified
+	if c.encrypter != nil {
+		encoded = append(Parameters{}, qualified...)
+		if err := c.encryptAll(ctx, qualified); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// split large values into chunks, if needed.
+	chunks, errs = c.chunkValues(qualified)
+
+	// validate params.
+	if err := qualified.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if errs != nil {
+		return nil, nil, nil, errs
+	}
+	return qualified, encoded, chunks, nil
+}
+
+// transform encodes, then encrypts, the value of each of the given
+// (qualified) params, as configured for this client.
+func (c *Client) transform(ctx context.Context, qualified Parameters) error {
+	if err := c.encodeAll(qualified); err != nil {
+		return err
+	}
+	return c.encryptAll(ctx, qualified)
+}
+
+// putInput converts the given (qualified) param into the input used to
+// upload it.
+func (c *Client) putInput(p Parameter) *ssm.PutParameterInput {
+	in := &ssm.PutParameterInput{
+		Name:      aws.String(p.Name),
+		Value:     aws.String(p.Value),
+		Type:      types.ParameterType(p.Type),
+		Overwrite: aws.Bool(p.Overwrite),
+	}
+
+	// add tier, if available.
+	if p.Tier != "" {
+		in.Tier = types.ParameterTier(p.Tier)
+	}
+
+	// add description, if available.
+	if p.Description != "" {
+		in.Description = aws.String(p.Description)
+	}
+
+	// add key id, if available.
+	switch {
+	case p.KeyId != "":
+		in.KeyId = aws.String(p.KeyId)
+	case c.keyId != "":
+		in.KeyId = aws.String(c.keyId)
+	}
+	return in
+}
+
+// classify retrieves the current value and metadata of each of the given
+// (qualified) params, in batches, setting the action (or error) in the
+// matching outcome: create if the param doesn't exist, no-op if it matches,
+// otherwise update. The given values are compared before being encrypted or
+// split into chunks, so current values are reassembled and decrypted first.
+func (c *Client) classify(ctx context.Context, qualified Parameters, out Outcomes) error {
+
+	// retrieve current params, with metadata.
+	current, err := c.snapshot(ctx, qualified.ToSliceString())
+	if err != nil {
+		return err
+	}
+	if c.largeValues || c.encrypter != nil {
+		values := make(Parameters, 0, len(current))
+		for _, p := range current {
+			values = append(values, p)
+		}
+		if c.largeValues {
+			if err := c.assemble(ctx, values, nil, nil); err != nil {
+				return err
+			}
+		}
+		// NOTE: current values that aren't encrypted yet are compared as-is,
+		// so they can still be replaced with encrypted values.
+		lenient := *c
+		lenient.requireEncryption = false
+		if err := lenient.decryptAll(ctx, values, nil); err != nil {
+			return err
+		}
+		for _, p := range values {
+			current[p.Name] = p
+		}
+	}
+
+	// classify params.
+	for i, p := range qualified {
+		cur, ok := current[p.Name]
+		if !ok {
+			out[i].Action = ChangeActionCreate
+			continue
+		}
+		switch {
+		case p.matches(cur) && (p.Overwrite || c.idempotentPuts):
+			out[i].Action = ChangeActionNoop
+		case !p.Overwrite:
+			out[i].Action = ChangeActionUpdate
+			out[i].Err = ErrAlreadyExists{out[i].Name}
+		default:
+			out[i].Action = ChangeActionUpdate
 		}
 	}
-	return errs
+	return nil
 }
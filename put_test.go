@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -108,3 +109,66 @@ func Test_Put(t *testing.T) {
 		})
 	}
 }
+
+func Test_PutIdempotent(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/app/name", Value: "myapp", Type: ParameterTypeString, Description: "The name."},
+		{Name: "/app/tier", Value: "big", Type: ParameterTypeString},
+	}
+	params := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/app/name", Value: "myapp", Type: ParameterTypeString, Description: "The new name.", Overwrite: true},
+		{Name: "/app/new", Value: "hello", Type: ParameterTypeString},
+		{Name: "/app/tier", Value: "big", Tier: ParameterTierAdvanced, Overwrite: true},
+	}
+	mock, s := newMockSSMStore(store)
+	puts := 0
+	putParameter := mock.PutParameterFunc
+	mock.PutParameterFunc = func(ctx context.Context, input *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+		puts++
+		return putParameter(ctx, input, optFns...)
+	}
+	c := &Client{logger: slog.Default(), batchSize: 2, ssmsvc: mock, idempotentPuts: true}
+
+	// put params, which should only upload the changes.
+	got, err := c.PutWithOutcomes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("PutWithOutcomes() returned an error; error=%v", err)
+	}
+	want := Outcomes{
+		{Name: "/app/host", Action: ChangeActionNoop},
+		{Name: "/app/name", Action: ChangeActionUpdate},
+		{Name: "/app/new", Action: ChangeActionCreate},
+		{Name: "/app/tier", Action: ChangeActionUpdate},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("PutWithOutcomes() returned unexpected outcomes;\nwant=%+v\ngot=%+v\n", want, got)
+	}
+	if puts != 3 {
+		t.Errorf("PutWithOutcomes() made an unexpected number of PutParameter calls; want=%v, got=%v", 3, puts)
+	}
+	history, _ := c.History(context.Background(), "/app/host")
+	if len(history) != 1 {
+		t.Errorf("PutWithOutcomes() added a version to an unchanged param; got=%v versions", len(history))
+	}
+
+	// put params again, which should upload nothing.
+	puts = 0
+	got, err = c.PutWithOutcomes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("PutWithOutcomes() returned an error; error=%v", err)
+	}
+	if n := got.Count(ChangeActionNoop); n != len(params) || puts != 0 {
+		t.Errorf("PutWithOutcomes() uploaded unchanged params; unchanged=%v, puts=%v", n, puts)
+	}
+
+	// catch changed param that can't be overwritten.
+	_, err = c.PutWithOutcomes(context.Background(), Parameters{{Name: "/app/host", Value: "db.staging"}})
+	if !errors.As(err, &ErrAlreadyExists{}) {
+		t.Errorf("PutWithOutcomes() returned an unexpected error; want=ErrAlreadyExists, got=%v", err)
+	}
+	if v, _ := s.Value("/app/host"); v != "db.prod" {
+		t.Errorf("PutWithOutcomes() overwrote a param; got=%v", v)
+	}
+}
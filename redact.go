@@ -0,0 +1,167 @@
+package paramstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// RedactionPolicy is how SecureString values are redacted whenever Parameters
+// are encoded, or a Plan is diffed, without being revealed.
+// NOTE: a Parameter that is printed or logged is always masked.
+type RedactionPolicy string
+
+const (
+	RedactionMask   RedactionPolicy = "mask"   // The value is replaced with "********".
+	RedactionHash   RedactionPolicy = "hash"   // The value is replaced with a short SHA-256 hash, so values can be compared.
+	RedactionLength RedactionPolicy = "length" // The value is replaced with its length.
+)
+
+// ParseRedactionPolicy converts the given string into a RedactionPolicy.
+func ParseRedactionPolicy(s string) (RedactionPolicy, error) {
+	switch p := RedactionPolicy(strings.ToLower(s)); p {
+	case RedactionMask, RedactionHash, RedactionLength:
+		return p, nil
+	}
+	return "", ErrInvalidRedactionPolicy{RedactionPolicy(s)}
+}
+
+// redact redacts the given value, using this policy; values are masked if no
+// policy is set.
+func (p RedactionPolicy) redact(value string) string {
+	switch p {
+	case RedactionHash:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:6])
+	case RedactionLength:
+		return fmt.Sprintf("<%d bytes>", len(value))
+	}
+	return maskedValue
+}
+
+// the patterns of the placeholders written by redact, other than maskedValue.
+var (
+	redactedHashPattern   = regexp.MustCompile(`^sha256:[0-9a-f]{12}$`)
+	redactedLengthPattern = regexp.MustCompile(`^<[0-9]+ bytes>$`)
+)
+
+// isRedacted returns true if the given value is a placeholder written by
+// redact, using any redaction policy.
+func isRedacted(value string) bool {
+	return value == maskedValue || redactedHashPattern.MatchString(value) || redactedLengthPattern.MatchString(value)
+}
+
+// Redacted returns a copy of this param, with the value masked if it's a
+// SecureString.
+func (p Parameter) Redacted() Parameter {
+	if p.Type == ParameterTypeSecureString {
+		p.Value = maskedValue
+	}
+	return p
+}
+
+// parameter is a Parameter, without its methods, so it can be printed by fmt.
+type parameter Parameter
+
+// String returns this param as a string, with SecureString values redacted.
+func (p Parameter) String() string {
+	return fmt.Sprintf("%+v", parameter(p.Redacted()))
+}
+
+// Format implements fmt.Formatter, so SecureString values are redacted by
+// every verb, including %+v and %#v.
+func (p Parameter) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, verb), parameter(p.Redacted()))
+}
+
+// LogValue implements slog.LogValuer, so SecureString values are redacted
+// when this param is logged.
+func (p Parameter) LogValue() slog.Value {
+	p = p.Redacted()
+	attrs := []slog.Attr{
+		slog.String("name", p.Name),
+		slog.String("type", string(p.Type)),
+		slog.String("value", p.Value),
+	}
+	if p.Version > 0 {
+		attrs = append(attrs, slog.Int64("version", p.Version))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Redacted returns a copy of this version, with the value masked if it's a
+// SecureString.
+func (v ParameterVersion) Redacted() ParameterVersion {
+	v.Parameter = v.Parameter.Redacted()
+	return v
+}
+
+// parameterVersion is a ParameterVersion, without its methods (or those of
+// its Parameter), so it can be printed by fmt.
+type parameterVersion struct {
+	Parameter parameter
+	Labels    []string
+}
+
+// String returns this version as a string, with SecureString values redacted.
+func (v ParameterVersion) String() string {
+	return fmt.Sprintf("%+v", v)
+}
+
+// Format implements fmt.Formatter, so SecureString values are redacted by
+// every verb, and the labels aren't dropped by the Format method of the
+// embedded Parameter.
+func (v ParameterVersion) Format(f fmt.State, verb rune) {
+	v = v.Redacted()
+	fmt.Fprintf(f, fmt.FormatString(f, verb), parameterVersion{parameter(v.Parameter), v.Labels})
+}
+
+// LogValue implements slog.LogValuer, so SecureString values are redacted
+// when this version is logged, along with its labels.
+func (v ParameterVersion) LogValue() slog.Value {
+	attrs := v.Parameter.LogValue().Group()
+	if len(v.Labels) > 0 {
+		attrs = append(attrs, slog.Any("labels", v.Labels))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Redacted returns a copy of this entry, with the value masked if it's from a
+// SecureString param.
+func (e TrashEntry) Redacted() TrashEntry {
+	if e.Type == ParameterTypeSecureString {
+		e.Value = maskedValue
+	}
+	return e
+}
+
+// trashEntry is a TrashEntry, without its methods, so it can be printed by
+// fmt.
+type trashEntry TrashEntry
+
+// String returns this entry as a string, with SecureString values redacted.
+func (e TrashEntry) String() string {
+	return fmt.Sprintf("%+v", trashEntry(e.Redacted()))
+}
+
+// Format implements fmt.Formatter, so SecureString values are redacted by
+// every verb, including %+v and %#v.
+func (e TrashEntry) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, verb), trashEntry(e.Redacted()))
+}
+
+// LogValue implements slog.LogValuer, so SecureString values are redacted
+// when this entry is logged.
+func (e TrashEntry) LogValue() slog.Value {
+	e = e.Redacted()
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+		slog.String("type", string(e.Type)),
+		slog.String("value", e.Value),
+		slog.Int64("version", e.Version),
+		slog.Time("deletedAt", e.DeletedAt),
+	)
+}
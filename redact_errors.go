@@ -0,0 +1,13 @@
+package paramstore
+
+import "fmt"
+
+// ErrInvalidRedactionPolicy is returned when a redaction policy isn't
+// supported.
+type ErrInvalidRedactionPolicy struct {
+	policy RedactionPolicy
+}
+
+func (e ErrInvalidRedactionPolicy) Error() string {
+	return fmt.Sprintf("%q is not a redaction policy; use mask, hash or length", e.policy)
+}
@@ -0,0 +1,180 @@
+package paramstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Redaction(t *testing.T) {
+	secret := Parameter{Name: "/myapp/db/password", Value: "hunter2", Type: ParameterTypeSecureString, Version: 3}
+	plain := Parameter{Name: "/myapp/db/host", Value: "db.prod", Type: ParameterTypeString}
+	version := ParameterVersion{Parameter: secret, Labels: []string{"stable"}}
+	entry := TrashEntry{Name: secret.Name, Value: secret.Value, Type: secret.Type, Version: secret.Version}
+
+	// check fmt, which always masks values.
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+		got := fmt.Sprintf(verb, secret)
+		if strings.Contains(got, secret.Value) || !strings.Contains(got, maskedValue) {
+			t.Errorf("Sprintf(%q) returned an unredacted value; want=%v, got=%v", verb, maskedValue, got)
+		}
+		if got := fmt.Sprintf(verb, Parameters{secret}); strings.Contains(got, secret.Value) {
+			t.Errorf("Sprintf(%q) returned an unredacted value for Parameters; got=%v", verb, got)
+		}
+		if got := fmt.Sprintf(verb, plain); !strings.Contains(got, plain.Value) {
+			t.Errorf("Sprintf(%q) redacted a String value; got=%v", verb, got)
+		}
+		if got := fmt.Sprintf(verb, version); strings.Contains(got, secret.Value) || !strings.Contains(got, "stable") {
+			t.Errorf("Sprintf(%q) returned an unexpected ParameterVersion; got=%v", verb, got)
+		}
+		if got := fmt.Sprintf(verb, ParameterHistory{version}); strings.Contains(got, secret.Value) {
+			t.Errorf("Sprintf(%q) returned an unredacted value for ParameterHistory; got=%v", verb, got)
+		}
+		if got := fmt.Sprintf(verb, entry); strings.Contains(got, secret.Value) || !strings.Contains(got, maskedValue) {
+			t.Errorf("Sprintf(%q) returned an unredacted value for TrashEntry; got=%v", verb, got)
+		}
+	}
+	for _, got := range []string{secret.String(), version.String(), entry.String()} {
+		if strings.Contains(got, secret.Value) {
+			t.Errorf("String() returned an unredacted value; got=%v", got)
+		}
+	}
+
+	// check slog, which always masks values.
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("test", "parameter", secret, "version", version, "entry", entry)
+	if strings.Contains(buf.String(), secret.Value) || !strings.Contains(buf.String(), maskedValue) {
+		t.Errorf("LogValue() returned an unredacted value; want=%v, got=%v", maskedValue, buf.String())
+	}
+	if !strings.Contains(buf.String(), "stable") {
+		t.Errorf("LogValue() dropped the labels of a ParameterVersion; got=%v", buf.String())
+	}
+}
+
+func Test_RedactionPolicy(t *testing.T) {
+	secret := Parameter{Name: "/myapp/db/password", Value: "hunter2", Type: ParameterTypeSecureString}
+	tests := map[string]struct {
+		policy RedactionPolicy
+		want   string
+	}{
+		"default": {
+			want: maskedValue,
+		},
+		"mask": {
+			policy: RedactionMask,
+			want:   maskedValue,
+		},
+		"hash": {
+			policy: RedactionHash,
+			want:   "sha256:f52fbd32b2b3",
+		},
+		"length": {
+			policy: RedactionLength,
+			want:   "<7 bytes>",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{logger: slog.Default()}
+			if tt.policy != "" {
+				if err := WithRedactionPolicy(tt.policy)(c); err != nil {
+					t.Fatalf("WithRedactionPolicy() returned an error; error=%v", err)
+				}
+			}
+			policy := c.RedactionPolicy()
+
+			// check encode.
+			var buf bytes.Buffer
+			if err := (Parameters{secret}).Encode(&buf, FormatDotenv, EncodeOptions{Redaction: policy}); err != nil {
+				t.Fatalf("Encode() returned an error; error=%v", err)
+			}
+			if got := buf.String(); strings.Contains(got, secret.Value) || !strings.Contains(got, tt.want) {
+				t.Errorf("Encode() returned an unexpected value; want=%v, got=%v", tt.want, got)
+			}
+
+			// check diff.
+			buf.Reset()
+			plan := Plan{Changes: []Change{{Action: ChangeActionCreate, Name: secret.Name, Desired: &secret}}}
+			if err := plan.Diff(&buf, policy); err != nil {
+				t.Fatalf("Diff() returned an error; error=%v", err)
+			}
+			if got := buf.String(); strings.Contains(got, secret.Value) || !strings.Contains(got, tt.want) {
+				t.Errorf("Diff() returned an unexpected value; want=%v, got=%v", tt.want, got)
+			}
+		})
+	}
+
+	// catch invalid policy.
+	if err := WithRedactionPolicy("reveal")(&Client{}); err == nil {
+		t.Errorf("WithRedactionPolicy() didn't return an error for an invalid policy")
+	}
+}
+
+func Test_Redaction_spans(t *testing.T) {
+
+	// setup tracing.
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(prev)
+
+	// setup client.
+	var logs bytes.Buffer
+	ctx := context.Background()
+	mock, _ := newMockSSMStore(nil)
+	c := &Client{
+		logger:    slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		batchSize: 10,
+		ssmsvc:    mock,
+	}
+	const secret = "hunter2-s3cr3t"
+	params := Parameters{
+		{Name: "/myapp/db/password", Value: secret, Type: ParameterTypeSecureString},
+		{Name: "/myapp/db/invalid", Value: secret + "\x00", Type: ParameterTypeSecureString, Tier: "Unknown"},
+	}
+	c.Put(ctx, params)
+	c.Put(ctx, params[:1])
+	c.GetMultiple(ctx, "/myapp/db/password", "/myapp/db/missing")
+	c.GetByPath(ctx, "/myapp", true)
+	c.PutIfAbsent(ctx, params[0])
+	c.Transaction(ctx, func(tx *Tx) error {
+		tx.Put(params[0])
+		return fmt.Errorf("abort")
+	})
+
+	// check spans.
+	spans := sr.Ended()
+	if len(spans) == 0 {
+		t.Fatalf("no spans were recorded")
+	}
+	for _, s := range spans {
+		for _, a := range s.Attributes() {
+			if strings.Contains(a.Value.Emit(), secret) {
+				t.Errorf("span %v has a value in attribute %v", s.Name(), a.Key)
+			}
+		}
+		for _, e := range s.Events() {
+			for _, a := range e.Attributes {
+				if strings.Contains(a.Value.Emit(), secret) {
+					t.Errorf("span %v has a value in event %v, attribute %v", s.Name(), e.Name, a.Key)
+				}
+			}
+		}
+		if strings.Contains(s.Status().Description, secret) {
+			t.Errorf("span %v has a value in its status", s.Name())
+		}
+	}
+
+	// check logs.
+	if strings.Contains(logs.String(), secret) {
+		t.Errorf("a value was logged; got=%v", logs.String())
+	}
+}
@@ -0,0 +1,123 @@
+package paramstore
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ReferencePrefix is the prefix of a value that references a parameter, such
+// as "ssm:///myapp/prod/db/password". A reference can end in a version or
+// label selector, such as "ssm:///myapp/prod/db/password:3" or
+// "ssm:///myapp/prod/db/password:stable".
+const ReferencePrefix = "ssm://"
+
+// ResolveReferences replaces every value in the given variables that is a
+// reference to a parameter with the value of that parameter. Every reference
+// is retrieved in batches, and every reference that can't be resolved is
+// returned in a single error. The given variables aren't modified.
+func (c *Client) ResolveReferences(ctx context.Context, vars map[string]string) (map[string]string, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "ResolveReferences")
+	defer span.End()
+
+	// find references.
+	refs := make(map[string]string)
+	var names []string
+	seen := make(map[string]bool)
+	for k, v := range vars {
+		name, ok := strings.CutPrefix(v, ReferencePrefix)
+		if !ok {
+			continue
+		}
+		refs[k] = name
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		// validate name, so an invalid reference is reported as unresolved
+		// below, instead of failing every reference.
+		q, err := c.qualify(name)
+		if err == nil {
+			err = validateGetNames([]string{q})
+		}
+		if err != nil {
+			c.logger.Warn("found invalid reference",
+				"error", err,
+				"variable", k,
+			)
+			continue
+		}
+		names = append(names, name)
+	}
+	out := make(map[string]string, len(vars))
+	for k, v := range vars {
+		out[k] = v
+	}
+	if len(names) == 0 {
+		return out, nil
+	}
+
+	// retrieve params.
+	// NOTE: invalid parameters are reported as unresolved references below.
+	sort.Strings(names)
+	params, err := c.GetMultiple(newCtx, names...)
+	if err != nil && !onlyInvalidParameters(err) {
+		return nil, err
+	}
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		values[p.Name+p.Selector] = p.Value
+	}
+
+	// replace references.
+	var unresolved []string
+	for k, name := range refs {
+		v, ok := values[name]
+		if !ok {
+			unresolved = append(unresolved, k+"="+ReferencePrefix+name)
+			continue
+		}
+		out[k] = v
+	}
+	if len(unresolved) > 0 {
+		sort.Strings(unresolved)
+		return nil, ErrUnresolvedReferences{unresolved}
+	}
+	return out, nil
+}
+
+// ResolveEnviron replaces every reference to a parameter in the given
+// environment, given in the form returned by os.Environ(), using
+// ResolveReferences. The resolved environment is returned in the same form,
+// sorted by key.
+func (c *Client) ResolveEnviron(ctx context.Context, environ []string) ([]string, error) {
+	resolved, err := c.ResolveReferences(ctx, environToMap(environ))
+	if err != nil {
+		return nil, err
+	}
+	return MergeEnv(nil, resolved, true), nil
+}
+
+// ApplyReferences replaces every reference to a parameter in the environment
+// of the current process, using ResolveReferences. If any reference can't be
+// resolved, the environment isn't modified.
+func (c *Client) ApplyReferences(ctx context.Context) error {
+	vars := environToMap(os.Environ())
+	resolved, err := c.ResolveReferences(ctx, vars)
+	if err != nil {
+		return err
+	}
+	for k, v := range resolved {
+		if vars[k] == v {
+			continue
+		}
+		if err := os.Setenv(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package paramstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrUnresolvedReferences is returned when one or more references to a
+// parameter can't be resolved.
+type ErrUnresolvedReferences struct {
+	References []string // Each unresolved reference, as KEY=ssm://NAME.
+}
+
+func (e ErrUnresolvedReferences) Error() string {
+	return fmt.Sprintf("failed to resolve references: %v", strings.Join(e.References, ", "))
+}
@@ -0,0 +1,114 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_ResolveReferences(t *testing.T) {
+	tests := map[string]struct {
+		vars map[string]string
+		want map[string]string
+		err  string
+	}{
+		"resolve references": {
+			vars: map[string]string{
+				"HOME":        "/root",
+				"DB_PASSWORD": "ssm:///myapp/prod/db/password",
+				"DB_HOST":     "ssm:///myapp/prod/db/host",
+				"DB_HOST_OLD": "ssm:///myapp/prod/db/host:1",
+				"DB_HOST_LBL": "ssm:///myapp/prod/db/host:stable",
+			},
+			want: map[string]string{
+				"HOME":        "/root",
+				"DB_PASSWORD": "hunter2",
+				"DB_HOST":     "db.prod",
+				"DB_HOST_OLD": "localhost",
+				"DB_HOST_LBL": "localhost",
+			},
+		},
+		"no references": {
+			vars: map[string]string{"HOME": "/root"},
+			want: map[string]string{"HOME": "/root"},
+		},
+		"catch unresolved references": {
+			vars: map[string]string{
+				"DB_HOST": "ssm:///myapp/prod/db/host",
+				"DB_USER": "ssm:///myapp/prod/db/user",
+				"DB_PORT": "ssm:///myapp/prod/db/host:9",
+			},
+			err: "failed to resolve references: DB_PORT=ssm:///myapp/prod/db/host:9, DB_USER=ssm:///myapp/prod/db/user",
+		},
+		"catch malformed references": {
+			vars: map[string]string{
+				"DB_HOST": "ssm:///myapp/prod/db/host",
+				"DB_USER": "ssm://myapp/prod/db/user",
+				"DB_NAME": "ssm:///myapp/prod/db name",
+			},
+			err: "failed to resolve references: DB_NAME=ssm:///myapp/prod/db name, DB_USER=ssm://myapp/prod/db/user",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestReferencesClient(t)
+			got, err := c.ResolveReferences(context.Background(), tt.vars)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("ResolveReferences() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ResolveReferences() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("ResolveReferences() returned unexpected variables;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_ResolveEnviron(t *testing.T) {
+	c := newTestReferencesClient(t)
+	got, err := c.ResolveEnviron(context.Background(), []string{"HOME=/root", "DB_HOST=ssm:///myapp/prod/db/host"})
+	if err != nil {
+		t.Fatalf("ResolveEnviron() returned an error; error=%v", err)
+	}
+	if want := []string{"DB_HOST=db.prod", "HOME=/root"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("ResolveEnviron() returned unexpected environment;\nwant=%v\ngot=%v\n", want, got)
+	}
+}
+
+func Test_ApplyReferences(t *testing.T) {
+	c := newTestReferencesClient(t)
+	t.Setenv("PARAMSTORE_TEST_DB_HOST", "ssm:///myapp/prod/db/host")
+	if err := c.ApplyReferences(context.Background()); err != nil {
+		t.Fatalf("ApplyReferences() returned an error; error=%v", err)
+	}
+	if got := os.Getenv("PARAMSTORE_TEST_DB_HOST"); got != "db.prod" {
+		t.Errorf("ApplyReferences() didn't apply reference; want=%v, got=%v", "db.prod", got)
+	}
+}
+
+// newTestReferencesClient is a helper function that returns a Client backed by
+// a store containing two versions of a parameter.
+func newTestReferencesClient(t *testing.T) *Client {
+	t.Helper()
+	mock, _ := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/db/host", Value: "localhost", Type: ParameterTypeString},
+		{Name: "/myapp/prod/db/password", Value: "hunter2", Type: ParameterTypeSecureString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 2, ssmsvc: mock}
+	ctx := context.Background()
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/db/host", Value: "db.prod", Overwrite: true}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if err := c.Label(ctx, "/myapp/prod/db/host", 1, "stable"); err != nil {
+		t.Fatalf("Label() returned an error; error=%v", err)
+	}
+	return c
+}
@@ -0,0 +1,95 @@
+package paramstore
+
+import (
+	"context"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// ResolvedParameter is a Parameter resolved from one of many layers.
+type ResolvedParameter struct {
+	Parameter        // The parameter, named using the key given to Resolve().
+	Layer     string // The layer (prefix) the value was resolved from.
+}
+
+// ResolvedParameters is a slice of ResolvedParameter.
+type ResolvedParameters []ResolvedParameter
+
+// ToParameters converts ResolvedParameters into Parameters.
+func (resolved ResolvedParameters) ToParameters() (out Parameters) {
+	for _, r := range resolved {
+		out = append(out, r.Parameter)
+	}
+	return out
+}
+
+// Resolve retrieves each of the given keys from a set of layers, where each
+// layer is a prefix (eg. "/myapp/default" or "/myapp/prod"). Layers are given
+// from least to most specific, so a key found in a later layer overrides the
+// same key found in an earlier layer. Keys that aren't found in any layer are
+// returned as errors.
+func (c *Client) Resolve(
+	ctx context.Context,
+	layers []string,
+	keys ...string,
+) (out ResolvedParameters, errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Resolve")
+	defer span.End()
+
+	// determine names to retrieve, for each layer.
+	cleaned := make([]string, len(layers))
+	var names []string
+	for i, l := range layers {
+		p, err := cleanPrefix(l)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		cleaned[i] = p
+		for _, k := range keys {
+			names = append(names, layerName(p, k))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	// retrieve params from every layer.
+	// NOTE: invalid parameters are expected here, since not every layer
+	// contains every key.
+	params, err := c.GetMultiple(newCtx, names...)
+	if err != nil && !onlyInvalidParameters(err) {
+		return nil, err
+	}
+	found := make(map[string]Parameter, len(params))
+	for _, p := range params {
+		found[p.Name] = p
+	}
+
+	// resolve each key from the most specific layer.
+	for _, k := range keys {
+		resolved := false
+		for i := len(cleaned) - 1; i >= 0; i-- {
+			p, ok := found[layerName(cleaned[i], k)]
+			if !ok {
+				continue
+			}
+			p.Name = k
+			out = append(out, ResolvedParameter{Parameter: p, Layer: cleaned[i]})
+			resolved = true
+			break
+		}
+		if !resolved {
+			errs = multierror.Append(errs, ErrUnresolvedKey{k})
+		}
+	}
+	return out, errs
+}
+
+// layerName returns the name of a key within a layer.
+func layerName(layer, key string) string {
+	return layer + "/" + strings.TrimLeft(key, "/")
+}
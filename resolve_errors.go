@@ -0,0 +1,13 @@
+package paramstore
+
+import "fmt"
+
+// ErrUnresolvedKey is returned when a key isn't found in any of the layers
+// given to Resolve().
+type ErrUnresolvedKey struct {
+	Key string
+}
+
+func (e ErrUnresolvedKey) Error() string {
+	return fmt.Sprintf("%q was not found in any layer", e.Key)
+}
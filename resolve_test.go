@@ -0,0 +1,74 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Resolve(t *testing.T) {
+	store := Parameters{
+		{Name: "/myapp/default/db/host", Value: "localhost", Type: ParameterTypeString},
+		{Name: "/myapp/default/db/port", Value: "5432", Type: ParameterTypeString},
+		{Name: "/myapp/prod/db/host", Value: "db.prod", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		layers []string
+		keys   []string
+		want   ResolvedParameters
+		err    string
+	}{
+		"resolve from most specific layer": {
+			layers: []string{"/myapp/default", "/myapp/prod"},
+			keys:   []string{"db/host", "db/port"},
+			want: ResolvedParameters{
+				{Parameter{Name: "db/host", Value: "db.prod", Type: ParameterTypeString}, "/myapp/prod"},
+				{Parameter{Name: "db/port", Value: "5432", Type: ParameterTypeString}, "/myapp/default"},
+			},
+		},
+		"resolve with reversed layers": {
+			layers: []string{"/myapp/prod", "/myapp/default/"},
+			keys:   []string{"/db/host"},
+			want: ResolvedParameters{
+				{Parameter{Name: "/db/host", Value: "localhost", Type: ParameterTypeString}, "/myapp/default"},
+			},
+		},
+		"catch unresolved key": {
+			layers: []string{"/myapp/default", "/myapp/prod"},
+			keys:   []string{"db/user"},
+			err:    `"db/user" was not found in any layer`,
+		},
+		"catch invalid layer": {
+			layers: []string{"myapp/default"},
+			keys:   []string{"db/host"},
+			err:    `invalid prefix "myapp/default"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{
+				logger:    slog.Default(),
+				batchSize: 10,
+				ssmsvc: &mockSSMClient{
+					GetParametersFunc: mockGetParametersFrom(store),
+				},
+			}
+			got, err := c.Resolve(context.Background(), tt.layers, tt.keys...)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Resolve() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Resolve() returned an error; error=%v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Resolve() returned unexpected parameters;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package paramstore
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// Tags is a map of tag keys to tag values.
+type Tags map[string]string
+
+// Tag adds (or overwrites) one or more tags on a single param in paramstore.
+func (c *Client) Tag(ctx context.Context, name string, tags Tags) error {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Tag")
+	defer span.End()
+
+	// qualify name.
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return err
+	}
+
+	// skip tagging, in dry-run mode.
+	if c.isDryRun(newCtx) {
+		c.logger.Info("dry run: would tag parameter",
+			"name", qualified,
+			"tags", tags,
+		)
+		return nil
+	}
+
+	// tag parameter.
+	in := &ssm.AddTagsToResourceInput{
+		ResourceId:   aws.String(qualified),
+		ResourceType: types.ResourceTypeForTaggingParameter,
+	}
+	for k, v := range tags {
+		in.Tags = append(in.Tags, types.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	if _, err := c.ssmsvc.AddTagsToResource(newCtx, in); err != nil {
+		c.logger.Error("failed to tag parameter",
+			"error", err,
+			"name", qualified,
+		)
+		return err
+	}
+	return nil
+}
+
+// Untag removes one or more tags from a single param in paramstore.
+func (c *Client) Untag(ctx context.Context, name string, keys ...string) error {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Untag")
+	defer span.End()
+
+	// qualify name.
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return err
+	}
+
+	// skip untagging, in dry-run mode.
+	if c.isDryRun(newCtx) {
+		c.logger.Info("dry run: would untag parameter",
+			"name", qualified,
+			"keys", keys,
+		)
+		return nil
+	}
+
+	// untag parameter.
+	in := &ssm.RemoveTagsFromResourceInput{
+		ResourceId:   aws.String(qualified),
+		ResourceType: types.ResourceTypeForTaggingParameter,
+		TagKeys:      keys,
+	}
+	if _, err := c.ssmsvc.RemoveTagsFromResource(newCtx, in); err != nil {
+		c.logger.Error("failed to untag parameter",
+			"error", err,
+			"name", qualified,
+			"keys", keys,
+		)
+		return err
+	}
+	return nil
+}
+
+// Tags retrieves the tags on a single param from paramstore.
+func (c *Client) Tags(ctx context.Context, name string) (Tags, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Tags")
+	defer span.End()
+
+	// qualify name.
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve tags.
+	in := &ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String(qualified),
+		ResourceType: types.ResourceTypeForTaggingParameter,
+	}
+	resp, err := c.ssmsvc.ListTagsForResource(newCtx, in)
+	if err != nil {
+		c.logger.Error("failed to list parameter tags",
+			"error", err,
+			"name", qualified,
+		)
+		return nil, err
+	}
+	out := make(Tags, len(resp.TagList))
+	for _, t := range resp.TagList {
+		out[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package paramstore
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_Tags(t *testing.T) {
+	mock, _ := newMockSSMStore(validTestdata.toParameters())
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	ctx := context.Background()
+	name := validTestdata.toParameter().Name
+
+	// add tags.
+	if err := c.Tag(ctx, name, Tags{"team": "platform", "env": "prod"}); err != nil {
+		t.Fatalf("Tag() returned an error; error=%v", err)
+	}
+	got, err := c.Tags(ctx, name)
+	if err != nil {
+		t.Fatalf("Tags() returned an error; error=%v", err)
+	}
+	if want := (Tags{"team": "platform", "env": "prod"}); !reflect.DeepEqual(want, got) {
+		t.Errorf("Tags() returned unexpected tags; want=%v, got=%v", want, got)
+	}
+
+	// remove tags.
+	if err := c.Untag(ctx, name, "env"); err != nil {
+		t.Fatalf("Untag() returned an error; error=%v", err)
+	}
+	got, err = c.Tags(ctx, name)
+	if err != nil {
+		t.Fatalf("Tags() returned an error; error=%v", err)
+	}
+	if want := (Tags{"team": "platform"}); !reflect.DeepEqual(want, got) {
+		t.Errorf("Tags() returned unexpected tags; want=%v, got=%v", want, got)
+	}
+
+	// catch missing parameter.
+	if _, err := c.Tags(ctx, "/missing"); err == nil {
+		t.Errorf("Tags() didn't return an error for a missing parameter")
+	}
+}
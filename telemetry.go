@@ -0,0 +1,82 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the attributes added to the spans created by this package.
+// NOTE: values are never added to spans, only names and counts.
+const (
+	attrOperation    = attribute.Key("paramstore.operation")       // The operation, or the AWS SSM call, traced.
+	attrCount        = attribute.Key("paramstore.parameter.count") // The number of params given to the operation or call.
+	attrBatchIndex   = attribute.Key("paramstore.batch.index")     // The index of the batch sent in a call.
+	attrInvalidCount = attribute.Key("paramstore.invalid.count")   // The number of params that don't exist.
+	attrDecryption   = attribute.Key("paramstore.decryption")      // If true, SecureString values are decrypted.
+	attrRetryCount   = attribute.Key("aws.retry.count")            // The number of times a call was retried.
+	attrRegion       = attribute.Key("cloud.region")               // The AWS region used.
+)
+
+// tracer returns the tracer used by this client, from the tracer provider
+// given to WithTracerProvider, or the global tracer provider otherwise.
+func (c *Client) tracer() trace.Tracer {
+	tp := c.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(c.tracerName)
+}
+
+// startSpan starts a span for the given operation, or AWS SSM call, with the
+// attributes shared by every span, plus the given attributes.
+func (c *Client) startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attrOperation.String(operation), attrRegion.String(c.awsRegion))
+	return c.tracer().Start(ctx, operation, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the given error, if any, on the given span, then ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// retryCount returns the number of times the call that returned the given
+// metadata was retried.
+func retryCount(metadata middleware.Metadata) int {
+	results, ok := retry.GetAttemptResults(metadata)
+	if !ok || len(results.Results) == 0 {
+		return 0
+	}
+	return len(results.Results) - 1
+}
+
+// errRetryCount returns the number of times the call that returned the given
+// error was retried, from the attempts recorded in the error by the AWS SDK.
+func errRetryCount(err error) int {
+	var maxErr *retry.MaxAttemptsError
+	if !errors.As(err, &maxErr) || maxErr.Attempt == 0 {
+		return 0
+	}
+	return maxErr.Attempt - 1
+}
+
+// invalidCount returns the number of the given outcomes for params that don't
+// exist.
+func invalidCount(outcomes Outcomes) (n int) {
+	for _, o := range outcomes {
+		if o.Action == ChangeActionMissing {
+			n++
+		}
+	}
+	return n
+}
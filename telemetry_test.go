@@ -0,0 +1,174 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanAttrs returns the attributes of the given span, keyed by name.
+func spanAttrs(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	out := make(map[attribute.Key]attribute.Value)
+	for _, a := range s.Attributes() {
+		out[a.Key] = a.Value
+	}
+	return out
+}
+
+func Test_Tracing(t *testing.T) {
+	ctx := context.Background()
+	var params Parameters
+	for i := 0; i < 12; i++ {
+		params = append(params, Parameter{Name: fmt.Sprintf("/myapp/%02d", i), Value: "v", Type: ParameterTypeString})
+	}
+	tests := map[string]struct {
+		fn        func(c *Client) error
+		operation string
+		batches   []string // The name of each child span.
+		count     int64
+		invalid   int64
+		err       bool
+	}{
+		"get multiple": {
+			fn: func(c *Client) error {
+				_, err := c.GetMultiple(ctx, params.ToSliceString()...)
+				return err
+			},
+			operation: "GetMultiple",
+			batches:   []string{"GetParameters", "GetParameters"},
+			count:     12,
+		},
+		"get multiple with invalid params": {
+			fn: func(c *Client) error {
+				_, err := c.GetMultiple(ctx, "/myapp/00", "/myapp/missing")
+				return err
+			},
+			operation: "GetMultiple",
+			batches:   []string{"GetParameters"},
+			count:     2,
+			invalid:   1,
+			err:       true,
+		},
+		"put": {
+			fn: func(c *Client) error {
+				return c.Put(ctx, Parameters{{Name: "/myapp/new", Value: "v", Type: ParameterTypeString}})
+			},
+			operation: "Put",
+			batches:   []string{"PutParameter"},
+			count:     1,
+		},
+		"delete": {
+			fn: func(c *Client) error {
+				return c.Delete(ctx, append(params.ToSliceString(), "/myapp/missing")...)
+			},
+			operation: "Delete",
+			batches:   []string{"DeleteParameters", "DeleteParameters"},
+			count:     13,
+			invalid:   1,
+			err:       true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+
+			// setup client.
+			sr := tracetest.NewSpanRecorder()
+			mock, _ := newMockSSMStore(params)
+			c := &Client{tracerName: "paramstore", awsRegion: "ap-southeast-2", logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			if err := WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))(c); err != nil {
+				t.Fatalf("WithTracerProvider() returned an error; error=%v", err)
+			}
+			if err := tt.fn(c); (err != nil) != tt.err {
+				t.Fatalf("%v() returned an unexpected error; want=%v, got=%v", tt.operation, tt.err, err)
+			}
+
+			// check parent span.
+			spans := sr.Ended()
+			parent := spans[len(spans)-1]
+			attrs := spanAttrs(parent)
+			if parent.Name() != tt.operation || attrs[attrOperation].AsString() != tt.operation {
+				t.Errorf("recorded an unexpected span; want=%v, got=%v", tt.operation, parent.Name())
+			}
+			if got := attrs[attrRegion].AsString(); got != "ap-southeast-2" {
+				t.Errorf("recorded an unexpected region; want=%v, got=%v", "ap-southeast-2", got)
+			}
+			if got := attrs[attrCount].AsInt64(); got != tt.count {
+				t.Errorf("recorded an unexpected count; want=%v, got=%v", tt.count, got)
+			}
+			if got := attrs[attrInvalidCount].AsInt64(); got != tt.invalid {
+				t.Errorf("recorded an unexpected invalid count; want=%v, got=%v", tt.invalid, got)
+			}
+			if got := parent.Status().Code == codes.Error; got != tt.err {
+				t.Errorf("recorded an unexpected status; want error=%v, got=%v", tt.err, parent.Status())
+			}
+
+			// check child spans.
+			var batches []sdktrace.ReadOnlySpan
+			for _, s := range spans {
+				if s.Parent().SpanID() == parent.SpanContext().SpanID() {
+					batches = append(batches, s)
+				}
+			}
+			if len(batches) != len(tt.batches) {
+				t.Fatalf("recorded an unexpected number of child spans; want=%v, got=%v", len(tt.batches), len(batches))
+			}
+			for i, s := range batches {
+				attrs := spanAttrs(s)
+				if s.Name() != tt.batches[i] {
+					t.Errorf("recorded an unexpected child span; want=%v, got=%v", tt.batches[i], s.Name())
+				}
+				got, ok := attrs[attrBatchIndex]
+				switch {
+				case s.Name() == "PutParameter" && ok:
+					t.Errorf("recorded a batch index for a single param call; got=%v", got.AsInt64())
+				case s.Name() != "PutParameter" && got.AsInt64() != int64(i):
+					t.Errorf("recorded an unexpected batch index; want=%v, got=%v", i, got.AsInt64())
+				}
+				if _, ok := attrs[attrRetryCount]; !ok {
+					t.Errorf("didn't record a retry count for %v", s.Name())
+				}
+			}
+		})
+	}
+}
+
+func Test_Tracing_failedBatch(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	mock := &mockSSMClient{
+		GetParametersFunc: func(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+			return nil, &retry.MaxAttemptsError{Attempt: 3, Err: errors.New("throttled")}
+		},
+	}
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, tracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))}
+	if _, err := c.GetMultiple(context.Background(), "/myapp/host"); err == nil {
+		t.Fatalf("GetMultiple() didn't return an error")
+	}
+	for _, s := range sr.Ended() {
+		if s.Status().Code != codes.Error {
+			t.Errorf("span %v has an unexpected status; want=%v, got=%v", s.Name(), codes.Error, s.Status().Code)
+		}
+		if len(s.Events()) == 0 || s.Events()[0].Name != "exception" {
+			t.Errorf("span %v didn't record the error", s.Name())
+		}
+		if s.Name() != "GetParameters" {
+			continue
+		}
+		if got := spanAttrs(s)[attrRetryCount].AsInt64(); got != 2 {
+			t.Errorf("span %v recorded an unexpected retry count; want=%v, got=%v", s.Name(), 2, got)
+		}
+	}
+
+	// catch nil tracer provider.
+	if err := WithTracerProvider(nil)(c); err == nil {
+		t.Errorf("WithTracerProvider() didn't return an error for a nil tracer provider")
+	}
+}
@@ -0,0 +1,280 @@
+package paramstore
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// the names of the functions in the template.FuncMap returned by TemplateFuncs.
+const (
+	templateFuncParam        = "param"
+	templateFuncParamList    = "paramList"
+	templateFuncParamsByPath = "paramsByPath"
+	templateFuncParamJSON    = "paramJSON"
+)
+
+// TemplateFuncs provides functions for text/template that look up parameters
+// using a Client. Parameters referenced by name in a template can be fetched
+// in batches, before the template is executed, via Prefetch; anything else is
+// fetched (and cached) as the template is executed.
+type TemplateFuncs struct {
+
+	// NOTE: template functions can't be given a context, so the context used
+	// for every lookup is kept here.
+	ctx context.Context
+	c   *Client
+
+	mu      sync.Mutex
+	params  map[string]Parameter  // The params fetched so far, keyed by name.
+	paths   map[string]Parameters // The params fetched so far, keyed by path.
+	missing map[string]bool       // The names prefetched that don't exist.
+}
+
+// NewTemplateFuncs creates and returns a new TemplateFuncs, which looks up
+// parameters using this client and the given context.
+func (c *Client) NewTemplateFuncs(ctx context.Context) *TemplateFuncs {
+	return &TemplateFuncs{
+		ctx:     ctx,
+		c:       c,
+		params:  make(map[string]Parameter),
+		paths:   make(map[string]Parameters),
+		missing: make(map[string]bool),
+	}
+}
+
+// FuncMap returns the functions to add to a template, via template.Funcs:
+//
+//   - param NAME: returns the value of a parameter.
+//   - paramList NAME: returns the value of a StringList parameter, as a slice.
+//   - paramsByPath PATH: returns every parameter under a path, as a map of
+//     names (relative to the path) to values.
+//   - paramJSON NAME: returns the value of a parameter, decoded from JSON.
+func (f *TemplateFuncs) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		templateFuncParam:        f.param,
+		templateFuncParamList:    f.paramList,
+		templateFuncParamsByPath: f.paramsByPath,
+		templateFuncParamJSON:    f.paramJSON,
+	}
+}
+
+// Prefetch scans the given parsed templates for parameters referenced by a
+// literal name or path, and fetches them all in batches. A parameter that
+// doesn't exist is returned as an ErrInvalidParameter, and again by any lookup
+// of it while the templates are executed.
+func (f *TemplateFuncs) Prefetch(templates ...*template.Template) (errs error) {
+
+	// setup tracing.
+	newCtx, span := f.c.tracer().Start(f.ctx, "Prefetch")
+	defer span.End()
+
+	// scan templates.
+	names := make(map[string]bool)
+	paths := make(map[string]bool)
+	for _, t := range templates {
+		for _, tt := range t.Templates() {
+			if tt.Tree != nil {
+				scanTemplateNode(tt.Tree.Root, names, paths)
+			}
+		}
+	}
+
+	// fetch params by name.
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var missing []string
+	for n := range names {
+		if _, ok := f.params[n]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		params, err := f.c.GetMultiple(newCtx, missing...)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		for _, p := range params {
+			f.params[p.Name+p.Selector] = p
+		}
+
+		// remember params that don't exist, so they aren't fetched again.
+		if err == nil || onlyInvalidParameters(err) {
+			for _, n := range missing {
+				if _, ok := f.params[n]; !ok {
+					f.missing[n] = true
+				}
+			}
+		}
+	}
+
+	// fetch params by path.
+	for p := range paths {
+		if _, ok := f.paths[p]; ok {
+			continue
+		}
+		params, err := f.c.GetByPath(newCtx, p, true)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		f.paths[p] = params
+	}
+	return errs
+}
+
+// scanTemplateNode walks the given template node, collecting every name and
+// path given as a literal string to one of the functions in FuncMap.
+func scanTemplateNode(node parse.Node, names, paths map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			scanTemplateNode(c, names, paths)
+		}
+	case *parse.ActionNode:
+		scanTemplateNode(n.Pipe, names, paths)
+	case *parse.IfNode:
+		scanTemplateNode(&n.BranchNode, names, paths)
+	case *parse.RangeNode:
+		scanTemplateNode(&n.BranchNode, names, paths)
+	case *parse.WithNode:
+		scanTemplateNode(&n.BranchNode, names, paths)
+	case *parse.BranchNode:
+		scanTemplateNode(n.Pipe, names, paths)
+		scanTemplateNode(n.List, names, paths)
+		scanTemplateNode(n.ElseList, names, paths)
+	case *parse.TemplateNode:
+		scanTemplateNode(n.Pipe, names, paths)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			scanTemplateNode(c, names, paths)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) >= 2 {
+			fn, isIdent := n.Args[0].(*parse.IdentifierNode)
+			arg, isString := n.Args[1].(*parse.StringNode)
+			if isIdent && isString {
+				switch fn.Ident {
+				case templateFuncParam, templateFuncParamList, templateFuncParamJSON:
+					names[arg.Text] = true
+				case templateFuncParamsByPath:
+					paths[arg.Text] = true
+				}
+			}
+		}
+		for _, a := range n.Args {
+			scanTemplateNode(a, names, paths)
+		}
+	}
+}
+
+// lookup returns a single parameter, fetching it if it hasn't been already.
+func (f *TemplateFuncs) lookup(name string) (Parameter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, ok := f.params[name]; ok {
+		return p, nil
+	}
+	if f.missing[name] {
+		return Parameter{}, ErrInvalidParameter{name}
+	}
+	p, err := f.c.Get(f.ctx, name)
+	if err != nil {
+		return Parameter{}, err
+	}
+	f.params[name] = *p
+	return *p, nil
+}
+
+// param returns the value of a parameter.
+func (f *TemplateFuncs) param(name string) (string, error) {
+	p, err := f.lookup(name)
+	if err != nil {
+		return "", err
+	}
+	return p.Value, nil
+}
+
+// paramList returns the value of a StringList parameter, as a slice.
+func (f *TemplateFuncs) paramList(name string) ([]string, error) {
+	p, err := f.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(p.Value, ","), nil
+}
+
+// paramJSON returns the value of a parameter, decoded from JSON.
+func (f *TemplateFuncs) paramJSON(name string) (any, error) {
+	p, err := f.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal([]byte(p.Value), &out); err != nil {
+		return nil, ErrInvalidValue{name, "value is not valid JSON: " + err.Error()}
+	}
+	return out, nil
+}
+
+// paramsByPath returns every parameter under a path, as a map of names
+// (relative to the path) to values.
+func (f *TemplateFuncs) paramsByPath(path string) (map[string]string, error) {
+	f.mu.Lock()
+	params, ok := f.paths[path]
+	f.mu.Unlock()
+	if !ok {
+		var err error
+		params, err = f.c.GetByPath(f.ctx, path, true)
+		if err != nil {
+			return nil, err
+		}
+		f.mu.Lock()
+		f.paths[path] = params
+		f.mu.Unlock()
+	}
+	out := make(map[string]string, len(params))
+	base := strings.TrimRight(path, "/") + "/"
+	for _, p := range params {
+		out[strings.TrimPrefix(p.Name, base)] = p.Value
+	}
+	return out, nil
+}
+
+// RenderTemplate parses the given template text, prefetches every parameter
+// it references, and executes it with the given data, writing the output to
+// the given writer. A parameter that doesn't exist only fails the render if
+// it's looked up, so it can be referenced in a branch that isn't taken.
+func (c *Client) RenderTemplate(ctx context.Context, w io.Writer, name, text string, data any) error {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "RenderTemplate")
+	defer span.End()
+
+	// parse template.
+	funcs := c.NewTemplateFuncs(newCtx)
+	t, err := template.New(name).Option("missingkey=error").Funcs(funcs.FuncMap()).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	// prefetch params, then execute template.
+	if err := funcs.Prefetch(t); err != nil && !onlyInvalidParameters(err) {
+		return err
+	}
+	return t.Execute(w, data)
+}
@@ -0,0 +1,108 @@
+package paramstore
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func Test_RenderTemplate(t *testing.T) {
+	store := Parameters{
+		{Name: "/nginx/server_name", Value: "example.com", Type: ParameterTypeString},
+		{Name: "/nginx/upstreams", Value: "10.0.0.1,10.0.0.2", Type: ParameterTypeStringList},
+		{Name: "/app/config", Value: `{"port":8080,"debug":true}`, Type: ParameterTypeString},
+		{Name: "/app/env/HOST", Value: "localhost", Type: ParameterTypeString},
+		{Name: "/app/env/PORT", Value: "8080", Type: ParameterTypeString},
+		{Name: "/app/key", Value: "port", Type: ParameterTypeString},
+	}
+	tests := map[string]struct {
+		text  string
+		want  string
+		calls int
+		err   bool
+	}{
+		"render param": {
+			text:  `server_name {{ param "/nginx/server_name" }};`,
+			want:  "server_name example.com;",
+			calls: 1,
+		},
+		"render paramList": {
+			text:  `{{ range paramList "/nginx/upstreams" }}server {{ . }};{{ end }}`,
+			want:  "server 10.0.0.1;server 10.0.0.2;",
+			calls: 1,
+		},
+		"render paramJSON": {
+			text:  `{{ with paramJSON "/app/config" }}listen {{ .port }}; debug {{ .debug }};{{ end }}`,
+			want:  "listen 8080; debug true;",
+			calls: 1,
+		},
+		"render paramsByPath": {
+			text:  `{{ range $k, $v := paramsByPath "/app/env" }}{{ $k }}={{ $v }};{{ end }}`,
+			want:  "HOST=localhost;PORT=8080;",
+			calls: 0,
+		},
+		"fetch referenced names in one batch": {
+			text:  `{{ param "/nginx/server_name" }} {{ param "/nginx/server_name" }} {{ if true }}{{ index (paramList "/nginx/upstreams") 0 }}{{ end }}`,
+			want:  "example.com example.com 10.0.0.1",
+			calls: 1,
+		},
+		"fetch dynamic names while executing": {
+			text:  `{{ $k := param "/app/key" }}{{ index (paramJSON "/app/config") $k }} {{ param (printf "/app/env/%v" "HOST") }}`,
+			want:  "8080 localhost",
+			calls: 2,
+		},
+		"skip missing parameter in a branch not taken": {
+			text:  `{{ if false }}{{ param "/nginx/missing" }}{{ else }}{{ param "/nginx/server_name" }}{{ end }}`,
+			want:  "example.com",
+			calls: 1,
+		},
+		"catch missing parameter": {
+			text:  `{{ param "/nginx/missing" }}`,
+			calls: 1,
+			err:   true,
+		},
+		"catch invalid JSON": {
+			text:  `{{ paramJSON "/nginx/server_name" }}`,
+			calls: 1,
+			err:   true,
+		},
+		"catch invalid template": {
+			text: `{{ param "/nginx/server_name" `,
+			err:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, _ := newMockSSMStore(store)
+			calls := 0
+			getParameters := mock.GetParametersFunc
+			mock.GetParametersFunc = func(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+				calls++
+				return getParameters(ctx, params, optFns...)
+			}
+			c := &Client{
+				logger:    slog.Default(),
+				batchSize: 10,
+				ssmsvc:    mock,
+			}
+			var buf bytes.Buffer
+			err := c.RenderTemplate(context.Background(), &buf, name, tt.text, nil)
+			if (err != nil) != tt.err {
+				t.Errorf("RenderTemplate() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				return
+			}
+			if calls != tt.calls {
+				t.Errorf("RenderTemplate() made an unexpected number of GetParameters calls; want=%v, got=%v", tt.calls, calls)
+			}
+			if tt.err {
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("RenderTemplate() returned unexpected output;\nwant=%q\ngot=%q\n", tt.want, got)
+			}
+		})
+	}
+}
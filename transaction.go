@@ -0,0 +1,273 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Tx stages the changes made in a transaction, which are only applied once the
+// function given to Transaction() returns.
+type Tx struct {
+	changes []txChange
+}
+
+// txChange is a single change staged in a transaction.
+type txChange struct {
+	action    ChangeAction // Either create (for a put) or delete.
+	parameter Parameter
+	chunks    Parameters // The chunks of a large value, put before the param.
+}
+
+// Put stages one or more params to be uploaded, in the same way as Put().
+func (tx *Tx) Put(parameters ...Parameter) {
+	for _, p := range parameters {
+		tx.changes = append(tx.changes, txChange{action: ChangeActionCreate, parameter: p})
+	}
+}
+
+// Delete stages one or more params to be deleted, in the same way as Delete().
+func (tx *Tx) Delete(names ...string) {
+	for _, n := range names {
+		tx.changes = append(tx.changes, txChange{action: ChangeActionDelete, parameter: Parameter{Name: n}})
+	}
+}
+
+// Transaction calls the given function to stage changes, then applies them in
+// the order they were staged. Before anything is changed, the latest value,
+// version and metadata of each param is retrieved; if any change fails, every
+// param already changed is restored to its prior value, and any param created
+// is deleted. The changes are only applied if the function returns nil. When
+// configured via WithTrash, deleted params are moved to the trash first, in the
+// same way as Delete().
+// NOTE:
+// A restored param gets a new version, since AWS SSM Parameter Store has no
+// way to remove a version, and the tags of a deleted param aren't restored. A
+// param changed by someone else during the transaction is left alone, and
+// reported in the rollback errors.
+func (c *Client) Transaction(ctx context.Context, fn func(tx *Tx) error) error {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Transaction")
+	defer span.End()
+
+	// stage changes.
+	tx := &Tx{}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.changes) == 0 {
+		return nil
+	}
+
+	// qualify names.
+	var errs error
+	var names []string
+	seen := make(map[string]bool)
+	var puts Parameters
+	var staged []int // The index of the change for each put.
+	for i, ch := range tx.changes {
+		name, err := c.qualify(ch.parameter.Name)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		tx.changes[i].parameter.Name = name
+		if ch.action == ChangeActionCreate {
+			puts = append(puts, tx.changes[i].parameter)
+			staged = append(staged, i)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	// validate params, before making any calls; the same param can be staged
+	// more than once.
+	if err := c.transform(newCtx, puts); err != nil {
+		return err
+	}
+	for i, p := range puts {
+		params := Parameters{p}
+		chunks, err := c.chunkValues(params)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if err := params.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		tx.changes[staged[i]].parameter = params[0]
+		tx.changes[staged[i]].chunks = chunks[p.Name]
+	}
+	if errs != nil {
+		return errs
+	}
+
+	// capture the prior state of each param.
+	prior, err := c.snapshot(newCtx, names)
+	if err != nil {
+		return err
+	}
+
+	// log changes, without applying them.
+	if c.isDryRun(newCtx) {
+		for _, ch := range tx.changes {
+			action := ch.action
+			if _, ok := prior[ch.parameter.Name]; ok && action == ChangeActionCreate {
+				action = ChangeActionUpdate
+			}
+			c.logger.Info("dry run: would apply change",
+				"name", ch.parameter.Name,
+				"action", string(action),
+			)
+		}
+		return nil
+	}
+
+	// apply changes, recording the latest version written for each param; 0
+	// once deleted.
+	written := make(map[string]int64)
+	final := make(map[string]string) // The value written last for each param; empty once deleted.
+	var order []string
+	for _, ch := range tx.changes {
+		name := ch.parameter.Name
+		version, err := c.applyChange(newCtx, ch)
+		if err != nil {
+			c.logger.Error("failed to apply change, rolling back transaction",
+				"error", err,
+				"name", name,
+				"action", string(ch.action),
+			)
+			rollbackCtx := context.WithoutCancel(newCtx)
+			if _, ok := written[name]; !ok && len(ch.chunks) > 0 {
+				c.discardChunks(rollbackCtx, name)
+			}
+			rollbackErr := c.rollback(rollbackCtx, order, prior, written)
+			return ErrTransactionFailed{Err: err, RollbackErr: rollbackErr}
+		}
+		if _, ok := written[name]; !ok {
+			order = append(order, name)
+		}
+		written[name] = version
+		final[name] = ch.parameter.Value
+	}
+
+	// delete chunks left over from a previous value, or a deleted param, if
+	// needed, unless the param can be restored from the trash.
+	if c.largeValues {
+		for _, name := range order {
+			if written[name] == 0 && c.trash != nil {
+				continue
+			}
+			m, _ := parseManifest(final[name])
+			if err := c.cleanupChunks(newCtx, name, m.Path); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// applyChange applies a single change staged in a transaction, returning the
+// version written; 0 for a delete.
+func (c *Client) applyChange(ctx context.Context, ch txChange) (int64, error) {
+	if ch.action == ChangeActionDelete {
+
+		// move param to the trash, if needed.
+		if c.trash != nil {
+			if _, failed := c.trashAll(ctx, []string{ch.parameter.Name}, nil); len(failed) > 0 {
+				return 0, failed[0].Err
+			}
+		}
+		resp, err := c.ssmsvc.DeleteParameters(ctx, &ssm.DeleteParametersInput{
+			Names: []string{ch.parameter.Name},
+		})
+		if err != nil {
+			return 0, err
+		}
+		if len(resp.InvalidParameters) > 0 {
+			return 0, ErrInvalidParameter{c.unqualify(ch.parameter.Name)}
+		}
+		return 0, nil
+	}
+
+	// put chunks first, so a manifest never refers to missing chunks.
+	if err := c.putChunks(ctx, ch.chunks); err != nil {
+		return 0, err
+	}
+	resp, err := c.ssmsvc.PutParameter(ctx, c.putInput(ch.parameter))
+	if err != nil {
+		var exists *types.ParameterAlreadyExists
+		if errors.As(err, &exists) {
+			return 0, ErrAlreadyExists{c.unqualify(ch.parameter.Name)}
+		}
+		return 0, err
+	}
+	return resp.Version, nil
+}
+
+// rollback restores the given (qualified) names to their prior state, in the
+// reverse order they were changed, skipping any param changed since it was
+// written by the transaction.
+func (c *Client) rollback(ctx context.Context, names []string, prior map[string]Parameter, written map[string]int64) (errs error) {
+	for i := len(names) - 1; i >= 0; i-- {
+		name := names[i]
+
+		// check the param wasn't changed by someone else.
+		latest, err := c.latestVersion(ctx, name)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if latest != written[name] {
+			errs = multierror.Append(errs, ErrVersionConflict{c.unqualify(name), written[name], latest})
+			continue
+		}
+
+		// restore the prior value, or delete the created param.
+		p, existed := prior[name]
+		switch {
+		case existed:
+			in := c.putInput(p)
+			in.Overwrite = aws.Bool(latest > 0)
+			_, err = c.ssmsvc.PutParameter(ctx, in)
+		case latest > 0:
+			_, err = c.ssmsvc.DeleteParameters(ctx, &ssm.DeleteParametersInput{
+				Names: []string{name},
+			})
+		}
+		if err != nil {
+			c.logger.Error("failed to roll back parameter",
+				"error", err,
+				"name", name,
+			)
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		// remove a restored param from the trash, if it was moved there.
+		if existed && written[name] == 0 && c.trash != nil {
+			if err := c.trash.Remove(ctx, name); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+
+		// delete chunks put by the transaction, keeping the chunks of the prior
+		// value, if needed.
+		if c.largeValues {
+			m, _ := parseManifest(p.Value)
+			if err := c.cleanupChunks(ctx, name, m.Path); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs
+}
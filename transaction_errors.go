@@ -0,0 +1,21 @@
+package paramstore
+
+import "fmt"
+
+// ErrTransactionFailed is returned when a change in a transaction fails, after
+// the changes already made were rolled back.
+type ErrTransactionFailed struct {
+	Err         error // The error that caused the transaction to fail.
+	RollbackErr error // The errors rolling back the changes already made, if any.
+}
+
+func (e ErrTransactionFailed) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("transaction failed: %v; rollback failed: %v", e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("transaction failed and was rolled back: %v", e.Err)
+}
+
+func (e ErrTransactionFailed) Unwrap() error {
+	return e.Err
+}
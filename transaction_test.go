@@ -0,0 +1,117 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func Test_Transaction(t *testing.T) {
+	store := Parameters{
+		{Name: "/app/host", Value: "db.prod", Type: ParameterTypeString, Description: "The host."},
+		{Name: "/app/old", Value: "legacy", Type: ParameterTypeString},
+	}
+	stage := func(tx *Tx) error {
+		tx.Put(
+			Parameter{Name: "/app/host", Value: "db.staging", Type: ParameterTypeString, Overwrite: true},
+			Parameter{Name: "/app/new", Value: "hello", Type: ParameterTypeString},
+		)
+		tx.Delete("/app/old")
+		return nil
+	}
+	tests := map[string]struct {
+		fn         func(tx *Tx) error
+		dryRun     bool
+		concurrent bool
+		stageErr   bool
+		err        error
+		rollback   bool
+		values     map[string]string
+	}{
+		"apply changes": {
+			fn:     stage,
+			values: map[string]string{"/app/host": "db.staging", "/app/new": "hello", "/app/old": ""},
+		},
+		"roll back changes": {
+			fn: func(tx *Tx) error {
+				_ = stage(tx)
+				tx.Delete("/app/missing")
+				return nil
+			},
+			err:    ErrInvalidParameter{"/app/missing"},
+			values: map[string]string{"/app/host": "db.prod", "/app/new": "", "/app/old": "legacy"},
+		},
+		"roll back around concurrent change": {
+			fn: func(tx *Tx) error {
+				_ = stage(tx)
+				tx.Delete("/app/missing")
+				return nil
+			},
+			concurrent: true,
+			err:        ErrInvalidParameter{"/app/missing"},
+			rollback:   true,
+			values:     map[string]string{"/app/host": "db.other", "/app/new": "", "/app/old": "legacy"},
+		},
+		"staging fails": {
+			fn: func(tx *Tx) error {
+				_ = stage(tx)
+				return errors.New("failed to stage")
+			},
+			stageErr: true,
+			values:   map[string]string{"/app/host": "db.prod", "/app/new": "", "/app/old": "legacy"},
+		},
+		"dry run": {
+			fn:     stage,
+			dryRun: true,
+			values: map[string]string{"/app/host": "db.prod", "/app/new": "", "/app/old": "legacy"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(store)
+			if tt.concurrent {
+				deleteParameters := mock.DeleteParametersFunc
+				mock.DeleteParametersFunc = func(ctx context.Context, input *ssm.DeleteParametersInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+					if input.Names[0] == "/app/missing" {
+						_, _ = s.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/host"), Value: aws.String("db.other"), Overwrite: aws.Bool(true)})
+					}
+					return deleteParameters(ctx, input, optFns...)
+				}
+			}
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, dryRun: tt.dryRun}
+			err := c.Transaction(context.Background(), tt.fn)
+			var failed ErrTransactionFailed
+			switch {
+			case tt.stageErr:
+				if err == nil || errors.As(err, &failed) {
+					t.Errorf("Transaction() returned an unexpected error; got=%v", err)
+				}
+			case tt.err == nil:
+				if err != nil {
+					t.Errorf("Transaction() returned an error; error=%v", err)
+				}
+			case !errors.As(err, &failed):
+				t.Errorf("Transaction() returned an unexpected error; want=ErrTransactionFailed, got=%v", err)
+			default:
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Transaction() returned an unexpected cause; want=%v, got=%v", tt.err, failed.Err)
+				}
+				if (failed.RollbackErr != nil) != tt.rollback {
+					t.Errorf("Transaction() returned unexpected rollback errors; got=%v", failed.RollbackErr)
+				}
+				if tt.rollback && !errors.As(failed.RollbackErr, &ErrVersionConflict{}) {
+					t.Errorf("Transaction() returned an unexpected rollback error; want=ErrVersionConflict, got=%v", failed.RollbackErr)
+				}
+			}
+			for n, want := range tt.values {
+				if got, _ := s.Value(n); got != want {
+					t.Errorf("Transaction() left an unexpected value for %v; want=%v, got=%v", n, want, got)
+				}
+			}
+		})
+	}
+}
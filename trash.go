@@ -0,0 +1,467 @@
+package paramstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// TrashEntry is a param deleted in soft-delete mode, archived with its
+// metadata so it can be restored.
+type TrashEntry struct {
+	Name        string        `json:"name"` // The full name of the param in paramstore.
+	Value       string        `json:"value"`
+	Type        ParameterType `json:"type"`
+	Tier        ParameterTier `json:"tier,omitempty"`
+	Description string        `json:"description,omitempty"`
+	KeyId       string        `json:"keyId,omitempty"`
+	Tags        Tags          `json:"tags,omitempty"`
+	Version     int64         `json:"version"` // The latest version of the param when it was deleted.
+	DeletedAt   time.Time     `json:"deletedAt"`
+}
+
+// Trash stores the params deleted in soft-delete mode, keyed by their full
+// name in paramstore.
+type Trash interface {
+	Put(ctx context.Context, entry TrashEntry) error
+	Get(ctx context.Context, name string) (TrashEntry, bool, error)
+	List(ctx context.Context) ([]TrashEntry, error)
+	Remove(ctx context.Context, name string) error
+}
+
+// paramstoreTrash is a Trash that stores each entry as a param under a prefix
+// in paramstore, using the same AWS SSM client as the client it belongs to.
+// The value of each entry is stored in its own param, next to the param with
+// the rest of the entry, so values up to the max size of a param fit.
+// SecureString params stay encrypted, with the same KMS key, while in the
+// trash.
+type paramstoreTrash struct {
+	c      *Client
+	prefix string
+}
+
+// the last segment of the name of the param the value of an entry is stored
+// in, under the name of the param with the rest of the entry.
+const trashValueSegment = "_value"
+
+// names returns the names of the params the entry for the given name is
+// archived as, under the trash prefix, failing if either breaks the naming
+// rules used by AWS SSM Parameter Store, such as being nested too deep.
+func (t *paramstoreTrash) names(name string) (entry, value string, err error) {
+	entry = t.prefix + "/" + strings.TrimLeft(name, "/")
+	value = entry + "/" + trashValueSegment
+	if err := validateName(value); err != nil {
+		return "", "", err
+	}
+	return entry, value, nil
+}
+
+// Put archives the given entry as params under the trash prefix, replacing
+// any entry with the same name.
+func (t *paramstoreTrash) Put(ctx context.Context, entry TrashEntry) error {
+	entryName, valueName, err := t.names(entry.Name)
+	if err != nil {
+		return err
+	}
+
+	// archive value.
+	in := &ssm.PutParameterInput{
+		Name:      aws.String(valueName),
+		Value:     aws.String(entry.Value),
+		Type:      types.ParameterType(entry.Type),
+		Tier:      types.ParameterTierIntelligentTiering,
+		Overwrite: aws.Bool(true),
+	}
+	if entry.Type == ParameterTypeSecureString && entry.KeyId != "" {
+		in.KeyId = aws.String(entry.KeyId)
+	}
+	if _, err := t.c.ssmsvc.PutParameter(ctx, in); err != nil {
+		return err
+	}
+
+	// archive the rest of the entry.
+	entry.Value = ""
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = t.c.ssmsvc.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(entryName),
+		Value:     aws.String(string(b)),
+		Type:      types.ParameterTypeString,
+		Tier:      types.ParameterTierIntelligentTiering,
+		Overwrite: aws.Bool(true),
+	})
+	return err
+}
+
+// Get retrieves the entry for the given name, if any.
+func (t *paramstoreTrash) Get(ctx context.Context, name string) (TrashEntry, bool, error) {
+	entryName, valueName, err := t.names(name)
+	if err != nil {
+		return TrashEntry{}, false, err
+	}
+	resp, err := t.c.ssmsvc.GetParameters(ctx, &ssm.GetParametersInput{
+		Names:          []string{entryName, valueName},
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return TrashEntry{}, false, err
+	}
+	var entry TrashEntry
+	var found bool
+	var value *string
+	for _, p := range resp.Parameters {
+		switch aws.ToString(p.Name) {
+		case entryName:
+			if err := json.Unmarshal([]byte(aws.ToString(p.Value)), &entry); err != nil {
+				return TrashEntry{}, false, ErrInvalidTrashEntry{name, err.Error()}
+			}
+			found = true
+		case valueName:
+			value = p.Value
+		}
+	}
+	if !found {
+		return TrashEntry{}, false, nil
+	}
+	if value != nil {
+		entry.Value = *value
+	}
+	return entry, true, nil
+}
+
+// List retrieves every entry under the trash prefix.
+func (t *paramstoreTrash) List(ctx context.Context) (out []TrashEntry, errs error) {
+	paginator := ssm.NewGetParametersByPathPaginator(t.c.ssmsvc, &ssm.GetParametersByPathInput{
+		Path:           aws.String(t.prefix),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	})
+	var names []string
+	values := make(map[string]string)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range resp.Parameters {
+			n := aws.ToString(p.Name)
+			if entryName, ok := strings.CutSuffix(n, "/"+trashValueSegment); ok {
+				values[entryName] = aws.ToString(p.Value)
+				continue
+			}
+			var entry TrashEntry
+			if err := json.Unmarshal([]byte(aws.ToString(p.Value)), &entry); err != nil {
+				errs = multierror.Append(errs, ErrInvalidTrashEntry{n, err.Error()})
+				continue
+			}
+			names = append(names, n)
+			out = append(out, entry)
+		}
+	}
+
+	// add values, stored separately.
+	for i, n := range names {
+		if v, ok := values[n]; ok {
+			out[i].Value = v
+		}
+	}
+	return out, errs
+}
+
+// Remove deletes the entry for the given name, if any.
+func (t *paramstoreTrash) Remove(ctx context.Context, name string) error {
+	entryName, valueName, err := t.names(name)
+	if err != nil {
+		return err
+	}
+	_, err = t.c.ssmsvc.DeleteParameters(ctx, &ssm.DeleteParametersInput{
+		Names: []string{entryName, valueName},
+	})
+	return err
+}
+
+// dirTrash is a Trash that stores each entry as a JSON file in a local
+// directory.
+type dirTrash struct {
+	dir string
+}
+
+// NewDirTrash returns a Trash that stores each entry as a JSON file in the
+// given local directory, which is created if needed.
+// NOTE: SecureString values are stored decrypted, so the files are only
+// readable by the current user.
+func NewDirTrash(dir string) Trash {
+	return &dirTrash{dir}
+}
+
+// path returns the path of the file for the given name.
+func (t *dirTrash) path(name string) string {
+	return filepath.Join(t.dir, url.PathEscape(name)+".json")
+}
+
+// Put writes the given entry to its own file, replacing any entry with the
+// same name.
+func (t *dirTrash) Put(ctx context.Context, entry TrashEntry) error {
+	if err := os.MkdirAll(t.dir, 0o700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path(entry.Name), b, 0o600)
+}
+
+// Get reads the entry for the given name, if any.
+func (t *dirTrash) Get(ctx context.Context, name string) (TrashEntry, bool, error) {
+	b, err := os.ReadFile(t.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return TrashEntry{}, false, nil
+	} else if err != nil {
+		return TrashEntry{}, false, err
+	}
+	var entry TrashEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return TrashEntry{}, false, ErrInvalidTrashEntry{name, err.Error()}
+	}
+	return entry, true, nil
+}
+
+// List reads every entry in the directory.
+func (t *dirTrash) List(ctx context.Context) (out []TrashEntry, errs error) {
+	files, err := os.ReadDir(t.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(t.dir, f.Name()))
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		var entry TrashEntry
+		if err := json.Unmarshal(b, &entry); err != nil {
+			errs = multierror.Append(errs, ErrInvalidTrashEntry{f.Name(), err.Error()})
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out, errs
+}
+
+// Remove deletes the file for the given name, if any.
+func (t *dirTrash) Remove(ctx context.Context, name string) error {
+	if err := os.Remove(t.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// trashAll archives each of the given (qualified) names in the trash, with
+// their metadata and tags, returning the names that can be deleted. Names
+// that don't exist are returned as-is, so they're reported as missing, while
+// names that fail to be archived are left out, with an outcome for each.
+func (c *Client) trashAll(ctx context.Context, names []string, given map[string]string) (keep []string, failed Outcomes) {
+
+	// retrieve current params, with metadata.
+	current, err := c.snapshot(ctx, names)
+	if err != nil {
+		for _, n := range names {
+			failed = append(failed, Outcome{Name: c.unqualifyFrom(given, n), Action: ChangeActionDelete, Err: err})
+		}
+		return nil, failed
+	}
+
+	// archive params.
+	raw := *c
+	raw.prefix, raw.nameTemplate = "", ""
+	deletedAt := time.Now().UTC()
+	for _, n := range names {
+		p, ok := current[n]
+		if !ok {
+			keep = append(keep, n)
+			continue
+		}
+		entry := TrashEntry{
+			Name:        n,
+			Value:       p.Value,
+			Type:        p.Type,
+			Tier:        p.Tier,
+			Description: p.Description,
+			KeyId:       p.KeyId,
+			Version:     p.Version,
+			DeletedAt:   deletedAt,
+		}
+		if entry.Tags, err = raw.Tags(ctx, n); err == nil {
+			err = c.trash.Put(ctx, entry)
+		}
+		if err != nil {
+			c.logger.Error("failed to move parameter to trash",
+				"error", err,
+				"name", n,
+			)
+			failed = append(failed, Outcome{Name: c.unqualifyFrom(given, n), Action: ChangeActionDelete, Err: err})
+			continue
+		}
+		keep = append(keep, n)
+	}
+	return keep, failed
+}
+
+// ListTrash retrieves every param in the trash, oldest first.
+func (c *Client) ListTrash(ctx context.Context) ([]TrashEntry, error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "ListTrash")
+	defer span.End()
+
+	// list entries.
+	if c.trash == nil {
+		return nil, ErrTrashNotConfigured{}
+	}
+	entries, err := c.trash.List(newCtx)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DeletedAt.Before(entries[j].DeletedAt) })
+	return entries, err
+}
+
+// Restore restores the given param from the trash, including its metadata and
+// tags, then removes it from the trash. The param mustn't exist.
+func (c *Client) Restore(ctx context.Context, name string) error {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "Restore")
+	defer span.End()
+
+	// qualify name.
+	if c.trash == nil {
+		return ErrTrashNotConfigured{}
+	}
+	qualified, err := c.qualify(name)
+	if err != nil {
+		return err
+	}
+
+	// retrieve entry.
+	entry, ok, err := c.trash.Get(newCtx, qualified)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotInTrash{name}
+	}
+
+	// log param, without restoring it.
+	if c.isDryRun(newCtx) {
+		c.logger.Info("dry run: would restore parameter",
+			"name", qualified,
+			"deletedAt", entry.DeletedAt,
+		)
+		return nil
+	}
+
+	// restore param.
+	in := c.putInput(Parameter{
+		Name:        qualified,
+		Value:       entry.Value,
+		Type:        entry.Type,
+		Tier:        entry.Tier,
+		Description: entry.Description,
+		KeyId:       entry.KeyId,
+	})
+	if _, err := c.ssmsvc.PutParameter(newCtx, in); err != nil {
+		var exists *types.ParameterAlreadyExists
+		if errors.As(err, &exists) {
+			return ErrAlreadyExists{name}
+		}
+		c.logger.Error("failed to restore parameter",
+			"error", err,
+			"name", qualified,
+		)
+		return err
+	}
+	if len(entry.Tags) > 0 {
+		raw := *c
+		raw.prefix, raw.nameTemplate = "", ""
+		if err := raw.Tag(newCtx, qualified, entry.Tags); err != nil {
+			return err
+		}
+	}
+	return c.trash.Remove(newCtx, qualified)
+}
+
+// purge removes the given (qualified) name from the trash, including any
+// chunks kept for it, unless the param has been restored.
+func (c *Client) purge(ctx context.Context, name string) error {
+	if err := c.trash.Remove(ctx, name); err != nil {
+		return err
+	}
+	if !c.largeValues {
+		return nil
+	}
+	version, err := c.latestVersion(ctx, name)
+	if err != nil || version > 0 {
+		return err
+	}
+	return c.cleanupChunks(ctx, name, "")
+}
+
+// PurgeTrash permanently removes every param deleted more than the given
+// duration ago from the trash, returning the names removed.
+func (c *Client) PurgeTrash(ctx context.Context, olderThan time.Duration) (purged []string, errs error) {
+
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "PurgeTrash")
+	defer span.End()
+
+	// list entries.
+	if c.trash == nil {
+		return nil, ErrTrashNotConfigured{}
+	}
+	entries, err := c.trash.List(newCtx)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	// remove expired entries.
+	cutoff := time.Now().Add(-olderThan)
+	dryRun := c.isDryRun(newCtx)
+	for _, entry := range entries {
+		if entry.DeletedAt.After(cutoff) {
+			continue
+		}
+		if dryRun {
+			c.logger.Info("dry run: would purge parameter from trash",
+				"name", entry.Name,
+				"deletedAt", entry.DeletedAt,
+			)
+		} else if err := c.purge(newCtx, entry.Name); err != nil {
+			c.logger.Error("failed to purge parameter from trash",
+				"error", err,
+				"name", entry.Name,
+			)
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		purged = append(purged, c.unqualify(entry.Name))
+	}
+	sort.Strings(purged)
+	return purged, errs
+}
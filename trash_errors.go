@@ -0,0 +1,30 @@
+package paramstore
+
+import "fmt"
+
+// ErrTrashNotConfigured is returned when the trash is used by a client that
+// isn't configured with one, via WithTrash or WithTrashPrefix.
+type ErrTrashNotConfigured struct{}
+
+func (e ErrTrashNotConfigured) Error() string {
+	return "no trash is configured"
+}
+
+// ErrNotInTrash is returned when a param being restored isn't in the trash.
+type ErrNotInTrash struct {
+	Name string
+}
+
+func (e ErrNotInTrash) Error() string {
+	return fmt.Sprintf("%q is not in the trash", e.Name)
+}
+
+// ErrInvalidTrashEntry is returned when an entry in the trash can't be read.
+type ErrInvalidTrashEntry struct {
+	Name   string
+	reason string
+}
+
+func (e ErrInvalidTrashEntry) Error() string {
+	return fmt.Sprintf("invalid trash entry %q: %v", e.Name, e.reason)
+}
@@ -0,0 +1,266 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingTrash is a Trash that fails to archive anything.
+type failingTrash struct {
+	Trash
+}
+
+func (t failingTrash) Put(ctx context.Context, entry TrashEntry) error {
+	return errors.New("trash is full")
+}
+
+func Test_Trash(t *testing.T) {
+	ctx := context.Background()
+	store := Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString, Description: "The database host."},
+		{Name: "/myapp/prod/password", Value: "hunter2", Type: ParameterTypeSecureString, KeyId: "alias/myapp"},
+	}
+	tests := map[string]func(t *testing.T, c *Client) error{
+		"trash prefix": func(t *testing.T, c *Client) error {
+			return WithTrashPrefix("/trash/")(c)
+		},
+		"local directory": func(t *testing.T, c *Client) error {
+			return WithTrash(NewDirTrash(t.TempDir()))(c)
+		},
+	}
+	for name, setup := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(store)
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			if err := setup(t, c); err != nil {
+				t.Fatalf("failed to configure trash; error=%v", err)
+			}
+			if err := c.Tag(ctx, "/myapp/prod/host", Tags{"team": "platform"}); err != nil {
+				t.Fatalf("Tag() returned an error; error=%v", err)
+			}
+
+			// soft delete params.
+			if err := c.Delete(ctx, "/myapp/prod/host", "/myapp/prod/password"); err != nil {
+				t.Fatalf("Delete() returned an error; error=%v", err)
+			}
+			if _, ok := s.Value("/myapp/prod/host"); ok {
+				t.Errorf("Delete() didn't delete the param")
+			}
+			entries, err := c.ListTrash(ctx)
+			if err != nil {
+				t.Fatalf("ListTrash() returned an error; error=%v", err)
+			}
+			if len(entries) != 2 {
+				t.Fatalf("ListTrash() returned an unexpected number of entries; want=2, got=%v", len(entries))
+			}
+			for _, e := range entries {
+				if e.Name == "/myapp/prod/password" && (e.Value != "hunter2" || e.KeyId != "alias/myapp") {
+					t.Errorf("Delete() archived an unexpected entry; got=%+v", e)
+				}
+			}
+
+			// restore param.
+			if err := c.Restore(ctx, "/myapp/prod/host"); err != nil {
+				t.Fatalf("Restore() returned an error; error=%v", err)
+			}
+			p, err := c.Get(ctx, "/myapp/prod/host")
+			if err != nil || p.Value != "db.prod" {
+				t.Errorf("Restore() didn't restore the param; got=%+v, error=%v", p, err)
+			}
+			tags, _ := c.Tags(ctx, "/myapp/prod/host")
+			if !reflect.DeepEqual(tags, Tags{"team": "platform"}) {
+				t.Errorf("Restore() didn't restore the tags; got=%v", tags)
+			}
+			m, _, _ := c.describeName(ctx, "/myapp/prod/host")
+			if d := m.Description; d == nil || *d != "The database host." {
+				t.Errorf("Restore() didn't restore the description; got=%v", d)
+			}
+			if err := c.Restore(ctx, "/myapp/prod/host"); !errors.As(err, &ErrNotInTrash{}) {
+				t.Errorf("Restore() returned an unexpected error; want=ErrNotInTrash, got=%v", err)
+			}
+
+			// purge trash.
+			purged, err := c.PurgeTrash(ctx, time.Hour)
+			if err != nil || len(purged) != 0 {
+				t.Errorf("PurgeTrash() purged recent params; got=%v, error=%v", purged, err)
+			}
+			purged, err = c.PurgeTrash(ctx, 0)
+			if err != nil || !reflect.DeepEqual(purged, []string{"/myapp/prod/password"}) {
+				t.Errorf("PurgeTrash() returned an unexpected result; got=%v, error=%v", purged, err)
+			}
+			if entries, _ := c.ListTrash(ctx); len(entries) != 0 {
+				t.Errorf("PurgeTrash() left entries in the trash; got=%+v", entries)
+			}
+		})
+	}
+}
+
+func Test_TrashRestoreExisting(t *testing.T) {
+	ctx := context.Background()
+	mock, _ := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, trash: NewDirTrash(t.TempDir())}
+	if err := c.Delete(ctx, "/myapp/prod/host"); err != nil {
+		t.Fatalf("Delete() returned an error; error=%v", err)
+	}
+	if err := c.Put(ctx, Parameters{{Name: "/myapp/prod/host", Value: "db.new", Type: ParameterTypeString}}); err != nil {
+		t.Fatalf("Put() returned an error; error=%v", err)
+	}
+	if err := c.Restore(ctx, "/myapp/prod/host"); !errors.As(err, &ErrAlreadyExists{}) {
+		t.Errorf("Restore() returned an unexpected error; want=ErrAlreadyExists, got=%v", err)
+	}
+}
+
+func Test_TrashFailure(t *testing.T) {
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, trash: failingTrash{}}
+	if err := c.Delete(context.Background(), "/myapp/prod/host"); err == nil {
+		t.Errorf("Delete() didn't return an error")
+	}
+	if _, ok := s.Value("/myapp/prod/host"); !ok {
+		t.Errorf("Delete() deleted a param that wasn't moved to the trash")
+	}
+}
+
+func Test_TrashTransaction(t *testing.T) {
+	ctx := context.Background()
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/host", Value: "db.prod", Type: ParameterTypeString},
+		{Name: "/myapp/prod/old", Value: "legacy", Type: ParameterTypeString},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock, trash: NewDirTrash(t.TempDir())}
+
+	// catch a failed transaction, which restores the param from the trash.
+	err := c.Transaction(ctx, func(tx *Tx) error {
+		tx.Delete("/myapp/prod/old", "/myapp/prod/missing")
+		return nil
+	})
+	if !errors.As(err, &ErrTransactionFailed{}) {
+		t.Fatalf("Transaction() returned an unexpected error; want=ErrTransactionFailed, got=%v", err)
+	}
+	if entries, err := c.ListTrash(ctx); err != nil || len(entries) != 0 {
+		t.Errorf("Transaction() left unexpected entries in the trash; got=%+v, err=%v", entries, err)
+	}
+
+	// soft delete param, in a transaction.
+	err = c.Transaction(ctx, func(tx *Tx) error {
+		tx.Put(Parameter{Name: "/myapp/prod/host", Value: "db.new", Type: ParameterTypeString, Overwrite: true})
+		tx.Delete("/myapp/prod/old")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction() returned an error; error=%v", err)
+	}
+	if _, ok := s.Value("/myapp/prod/old"); ok {
+		t.Errorf("Transaction() didn't delete the param")
+	}
+
+	// restore param from the trash.
+	if err := c.Restore(ctx, "/myapp/prod/old"); err != nil {
+		t.Fatalf("Restore() returned an error; error=%v", err)
+	}
+	if got, _ := s.Value("/myapp/prod/old"); got != "legacy" {
+		t.Errorf("Restore() restored an unexpected value; want=legacy, got=%v", got)
+	}
+}
+
+func Test_TrashNames(t *testing.T) {
+	ctx := context.Background()
+	tests := map[string]struct {
+		name    string
+		trashed string
+		wantErr bool
+	}{
+		"hierarchical name": {
+			name:    "/myapp/prod/host",
+			trashed: "/trash/myapp/prod/host",
+		},
+		"flat name": {
+			name:    "host",
+			trashed: "/trash/host",
+		},
+		"name too deep for the trash": {
+			name:    "/a/b/c/d/e/f/g/h/i/j/k/l/m/n/o",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock, s := newMockSSMStore(Parameters{
+				{Name: tt.name, Value: "db.prod", Type: ParameterTypeString},
+			})
+			c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+			if err := WithTrashPrefix("/trash")(c); err != nil {
+				t.Fatalf("failed to configure trash; error=%v", err)
+			}
+
+			// soft delete param.
+			err := c.Delete(ctx, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Delete() returned an unexpected error; wantErr=%v, got=%v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				if _, ok := s.Value(tt.name); !ok {
+					t.Errorf("Delete() deleted a param that wasn't moved to the trash")
+				}
+				return
+			}
+			if _, ok := s.Value(tt.trashed); !ok {
+				t.Errorf("Delete() didn't archive the param; want=%v, got=%v", tt.trashed, s.Names())
+			}
+
+			// restore param.
+			if err := c.Restore(ctx, tt.name); err != nil {
+				t.Fatalf("Restore() returned an error; error=%v", err)
+			}
+			if v, _ := s.Value(tt.name); v != "db.prod" {
+				t.Errorf("Restore() didn't restore the param; got=%v", v)
+			}
+		})
+	}
+}
+
+func Test_TrashLargeValue(t *testing.T) {
+	ctx := context.Background()
+	value := strings.Repeat("\"\n", maxAdvancedValueSize/2)
+	mock, s := newMockSSMStore(Parameters{
+		{Name: "/myapp/prod/cert", Value: value, Type: ParameterTypeSecureString, Tier: ParameterTierAdvanced, Description: "The certificate."},
+	})
+	c := &Client{logger: slog.Default(), batchSize: 10, ssmsvc: mock}
+	if err := WithTrashPrefix("/trash")(c); err != nil {
+		t.Fatalf("failed to configure trash; error=%v", err)
+	}
+
+	// soft delete param, which mustn't store more than fits in a param.
+	if err := c.Delete(ctx, "/myapp/prod/cert"); err != nil {
+		t.Fatalf("Delete() returned an error; error=%v", err)
+	}
+	for _, n := range s.Names() {
+		if v, _ := s.Value(n); len(v) > maxAdvancedValueSize {
+			t.Errorf("Delete() archived a value too large for a param; name=%v, got=%v bytes", n, len(v))
+		}
+	}
+	entries, err := c.ListTrash(ctx)
+	if err != nil || len(entries) != 1 || entries[0].Value != value || entries[0].Description != "The certificate." {
+		t.Fatalf("ListTrash() returned an unexpected result; got=%v entries, error=%v", len(entries), err)
+	}
+
+	// restore param.
+	if err := c.Restore(ctx, "/myapp/prod/cert"); err != nil {
+		t.Fatalf("Restore() returned an error; error=%v", err)
+	}
+	if v, _ := s.Value("/myapp/prod/cert"); v != value {
+		t.Errorf("Restore() didn't restore the value; got=%v bytes", len(v))
+	}
+	if want := []string{"/myapp/prod/cert"}; !reflect.DeepEqual(want, s.Names()) {
+		t.Errorf("Restore() left the entry in the trash; got=%v", s.Names())
+	}
+}
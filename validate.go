@@ -0,0 +1,119 @@
+package paramstore
+
+import (
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// the max size of a value for a parameter in the standard tier.
+	maxStandardValueSize = 4 * 1024
+
+	// the max size of a value for a parameter in the advanced tier.
+	maxAdvancedValueSize = 8 * 1024
+
+	// the max length of the description of a parameter.
+	maxDescriptionLength = 1024
+)
+
+// the prefixes reserved by AWS, which parameters cannot be created under.
+var reservedNamePrefixes = []string{"aws", "ssm"}
+
+// Validate checks the parameter against the rules used by AWS SSM Parameter
+// Store when uploading a parameter, returning every rule the parameter breaks.
+func (p Parameter) Validate() (errs error) {
+
+	// check name.
+	for _, err := range nameViolations(p.Name) {
+		errs = multierror.Append(errs, err)
+	}
+	trimmed := strings.ToLower(strings.TrimLeft(p.Name, "/"))
+	for _, r := range reservedNamePrefixes {
+		if strings.HasPrefix(trimmed, r) {
+			errs = multierror.Append(errs, ErrInvalidName{p.Name, fmt.Sprintf("name cannot start with %q", r)})
+		}
+	}
+
+	// check type.
+	switch p.Type {
+	case "", ParameterTypeString, ParameterTypeSecureString:
+	case ParameterTypeStringList:
+		for _, item := range strings.Split(p.Value, ",") {
+			if item == "" {
+				errs = multierror.Append(errs, ErrInvalidValue{p.Name, "StringList values cannot contain empty items"})
+				break
+			}
+		}
+	default:
+		errs = multierror.Append(errs, ErrInvalidType{p.Name, p.Type})
+	}
+
+	// check tier.
+	switch p.Tier {
+	case "", ParameterTierStandard, ParameterTierAdvanced, ParameterTierIntelligentTiering:
+	default:
+		errs = multierror.Append(errs, ErrInvalidTier{p.Name, p.Tier})
+	}
+	limit := valueLimit(p.Tier)
+
+	// check value.
+	switch {
+	case p.Value == "":
+		errs = multierror.Append(errs, ErrInvalidValue{p.Name, "value cannot be empty"})
+	case len(p.Value) > limit:
+		errs = multierror.Append(errs, ErrInvalidValue{
+			p.Name,
+			fmt.Sprintf("value is %v bytes, which is more than the %v bytes allowed", len(p.Value), limit),
+		})
+	}
+
+	// check description.
+	if len(p.Description) > maxDescriptionLength {
+		errs = multierror.Append(errs, ErrInvalidDescription{
+			p.Name,
+			fmt.Sprintf("description is %v characters, which is more than the %v allowed", len(p.Description), maxDescriptionLength),
+		})
+	}
+	return errs
+}
+
+// Validate checks each parameter using Parameter.Validate(), and checks that
+// no two parameters share the same name, returning every rule broken.
+func (parameters Parameters) Validate() (errs error) {
+	seen := make(map[string]bool, len(parameters))
+	for _, p := range parameters {
+		if err := p.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if seen[p.Name] {
+			errs = multierror.Append(errs, ErrDuplicateName{p.Name})
+		}
+		seen[p.Name] = true
+	}
+	return errs
+}
+
+// validateGetNames checks the given names against the rules used by AWS SSM
+// Parameter Store when retrieving parameters, returning every rule broken.
+// NOTE: unlike Parameter.Validate(), this allows ARNs, version or label
+// selectors (eg. "/name:1") and names under the reserved prefixes, since
+// these can all be retrieved.
+func validateGetNames(names []string) (errs error) {
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		if seen[n] {
+			errs = multierror.Append(errs, ErrDuplicateName{n})
+		}
+		seen[n] = true
+		if strings.HasPrefix(n, "arn:") {
+			continue
+		}
+		name, _, _ := strings.Cut(n, ":")
+		for _, err := range nameViolations(name) {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
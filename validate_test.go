@@ -0,0 +1,145 @@
+package paramstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/go-multierror"
+)
+
+func Test_ParameterValidate(t *testing.T) {
+	tests := map[string]struct {
+		parameter Parameter
+		errs      []string
+	}{
+		"valid parameter": {
+			parameter: validTestdata.toParameters()[0],
+		},
+		"valid advanced parameter": {
+			parameter: Parameter{Name: "/big", Value: strings.Repeat("a", 8*1024), Tier: ParameterTierAdvanced},
+		},
+		"catch reserved prefix": {
+			parameter: Parameter{Name: "/AWS/thing", Value: "v"},
+			errs:      []string{`invalid name "/AWS/thing": name cannot start with "aws"`},
+		},
+		"catch every name violation": {
+			parameter: Parameter{Name: "ssm/a b", Value: "v"},
+			errs: []string{
+				`invalid name "ssm/a b": name can only contain letters, numbers and the symbols "_.-/"`,
+				`invalid name "ssm/a b": hierarchical names must start with "/"`,
+				`invalid name "ssm/a b": name cannot start with "ssm"`,
+			},
+		},
+		"catch oversized standard value": {
+			parameter: Parameter{Name: "/big", Value: strings.Repeat("a", 4*1024+1)},
+			errs:      []string{`invalid value for "/big": value is 4097 bytes, which is more than the 4096 bytes allowed`},
+		},
+		"catch oversized advanced value": {
+			parameter: Parameter{Name: "/big", Value: strings.Repeat("a", 8*1024+1), Tier: ParameterTierIntelligentTiering},
+			errs:      []string{`invalid value for "/big": value is 8193 bytes, which is more than the 8192 bytes allowed`},
+		},
+		"catch empty value": {
+			parameter: Parameter{Name: "/empty"},
+			errs:      []string{`invalid value for "/empty": value cannot be empty`},
+		},
+		"catch empty string list item": {
+			parameter: Parameter{Name: "/list", Value: "a,,b", Type: ParameterTypeStringList},
+			errs:      []string{`invalid value for "/list": StringList values cannot contain empty items`},
+		},
+		"catch oversized description": {
+			parameter: Parameter{Name: "/thing", Value: "v", Description: strings.Repeat("a", 1025)},
+			errs:      []string{`invalid description for "/thing": description is 1025 characters, which is more than the 1024 allowed`},
+		},
+		"catch invalid type and tier": {
+			parameter: Parameter{Name: "/thing", Value: "v", Type: "Number", Tier: "Premium"},
+			errs: []string{
+				`invalid type for "/thing": "Number" is not a parameter type`,
+				`invalid tier for "/thing": "Premium" is not a parameter tier`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checkViolations(t, "Validate()", tt.parameter.Validate(), tt.errs)
+		})
+	}
+}
+
+func Test_ParametersValidate(t *testing.T) {
+	params := validTestdata.toParameters()
+	checkViolations(t, "Validate()", params.Validate(), nil)
+	checkViolations(t, "Validate()", append(params, params[0]).Validate(), []string{
+		`"/hello" is given more than once`,
+	})
+}
+
+func Test_PutValidatesBeforeCalls(t *testing.T) {
+	calls := 0
+	c := &Client{
+		logger:    slog.Default(),
+		batchSize: 10,
+		ssmsvc: &mockSSMClient{
+			PutParameterFunc: func(ctx context.Context, input *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+				calls++
+				return &ssm.PutParameterOutput{}, nil
+			},
+		},
+	}
+	params := append(validTestdata.toParameters(), Parameter{Name: "/empty"})
+	if err := c.Put(context.Background(), params); err == nil {
+		t.Errorf("Put() didn't return an error for an invalid parameter")
+	}
+	if calls != 0 {
+		t.Errorf("Put() made %v calls, before validating parameters", calls)
+	}
+}
+
+// checkViolations is a helper function that compares the errors returned by
+// a validation function with the expected errors.
+func checkViolations(t *testing.T, fn string, err error, want []string) {
+	t.Helper()
+	var got []string
+	var errs *multierror.Error
+	if errors.As(err, &errs) {
+		for _, e := range errs.Errors {
+			got = append(got, e.Error())
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("%v returned unexpected number of errors;\nwant=%v\ngot=%v\n", fn, want, got)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%v got unexpected error;\nwant=%v\ngot=%v\n", fn, want[i], got[i])
+		}
+	}
+}
+
+func Test_validateGetNames(t *testing.T) {
+	tests := map[string]struct {
+		names []string
+		errs  []string
+	}{
+		"valid names": {
+			names: []string{"/hello", "/aws/service/ami", "/hello:1", "/hello:latest", "arn:aws:ssm:ap-southeast-2:123456789012:parameter/shared"},
+		},
+		"catch duplicate names": {
+			names: []string{"/hello", "/hello"},
+			errs:  []string{`"/hello" is given more than once`},
+		},
+		"catch invalid names": {
+			names: []string{"/hello world"},
+			errs:  []string{`invalid name "/hello world": name can only contain letters, numbers and the symbols "_.-/"`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checkViolations(t, "validateGetNames()", validateGetNames(tt.names), tt.errs)
+		})
+	}
+}